@@ -1,6 +1,7 @@
 package vocdriver
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -11,17 +12,17 @@ type CustomerAccountService struct {
 	Endpoint string
 }
 
-func (s *CustomerAccountService) GetAccount() (customerAccount *CustomerAccount, err error) {
+func (s *CustomerAccountService) GetAccount(ctx context.Context) (customerAccount *CustomerAccount, err error) {
 	url := s.client.MakeURL(s.Endpoint)
-	if _, err = s.client.Request.Get(url, &customerAccount); err != nil {
+	if _, err = s.client.Request.Get(ctx, url, &customerAccount); err != nil {
 		return
 	}
 	customerAccount.client = s.client
 	return
 }
 
-func (s *CustomerAccountService) GetAccountByHyperlink(url string) (customerAccount *CustomerAccount, err error) {
-	if _, err = s.client.Request.Get(url, &customerAccount); err != nil {
+func (s *CustomerAccountService) GetAccountByHyperlink(ctx context.Context, url string) (customerAccount *CustomerAccount, err error) {
+	if _, err = s.client.Request.Get(ctx, url, &customerAccount); err != nil {
 		return
 	}
 	customerAccount.client = s.client
@@ -41,10 +42,10 @@ type CustomerAccount struct {
 	accountVehicleRelationsRetrieved bool
 }
 
-func (ca *CustomerAccount) RetrieveHyperlinks() (err error) {
+func (ca *CustomerAccount) RetrieveHyperlinks(ctx context.Context) (err error) {
 	if !ca.accountVehicleRelationsRetrieved {
 		for _, url := range ca.HyperlinkAccountVehicleRelations {
-			relation, err := ca.client.AccountVehicleRelation.GetByHyperlink(url)
+			relation, err := ca.client.AccountVehicleRelation.GetByHyperlink(ctx, url)
 			if err != nil {
 				return err
 			}
@@ -67,13 +68,13 @@ func (ca CustomerAccount) GetAccountVehicleRelationIds() (relationIds []int, err
 	return
 }
 
-func (ca *CustomerAccount) GetAccountVehicleRelations() (relations []AccountVehicleRelation, err error) {
+func (ca *CustomerAccount) GetAccountVehicleRelations(ctx context.Context) (relations []AccountVehicleRelation, err error) {
 	relationIds, err := ca.GetAccountVehicleRelationIds()
 	if err != nil {
 		return
 	}
 	for _, id := range relationIds {
-		relation, err := ca.client.AccountVehicleRelation.GetById(id)
+		relation, err := ca.client.AccountVehicleRelation.GetById(ctx, id)
 		if err != nil {
 			return relations, err
 		}
@@ -82,14 +83,14 @@ func (ca *CustomerAccount) GetAccountVehicleRelations() (relations []AccountVehi
 	return
 }
 
-func (ca *CustomerAccount) GetVehicles() (vehicles []Vehicle, err error) {
-	accountVehicleRelations, err := ca.GetAccountVehicleRelations()
+func (ca *CustomerAccount) GetVehicles(ctx context.Context) (vehicles []Vehicle, err error) {
+	accountVehicleRelations, err := ca.GetAccountVehicleRelations(ctx)
 	if err != nil {
 		return
 	}
 
 	for _, relation := range accountVehicleRelations {
-		vehicle, err := ca.client.Vehicles.GetVehicleByVIN(relation.VehicleID)
+		vehicle, err := ca.client.Vehicles.GetVehicleByVIN(ctx, relation.VehicleID)
 		if err != nil {
 			return vehicles, err
 		}
@@ -1,23 +1,26 @@
 package vocdriver
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 type AccountVehicleRelationsService struct {
 	client   *Client
 	Endpoint string
 }
 
-func (s *AccountVehicleRelationsService) GetById(customerVehicleRelationId int) (vehicleAccRel *AccountVehicleRelation, err error) {
+func (s *AccountVehicleRelationsService) GetById(ctx context.Context, customerVehicleRelationId int) (vehicleAccRel *AccountVehicleRelation, err error) {
 	url := s.client.MakeURL(s.Endpoint, fmt.Sprintf("%d", customerVehicleRelationId))
-	if _, err = s.client.Request.Get(url, &vehicleAccRel); err != nil {
+	if _, err = s.client.Request.Get(ctx, url, &vehicleAccRel); err != nil {
 		return
 	}
 	vehicleAccRel.client = s.client
 	return
 }
 
-func (s *AccountVehicleRelationsService) GetByHyperlink(url string) (vehicleAccRel *AccountVehicleRelation, err error) {
-	if _, err = s.client.Request.Get(url, &vehicleAccRel); err != nil {
+func (s *AccountVehicleRelationsService) GetByHyperlink(ctx context.Context, url string) (vehicleAccRel *AccountVehicleRelation, err error) {
+	if _, err = s.client.Request.Get(ctx, url, &vehicleAccRel); err != nil {
 		return
 	}
 	vehicleAccRel.client = s.client
@@ -38,14 +41,14 @@ type AccountVehicleRelation struct {
 	client                          *Client
 }
 
-func (avr *AccountVehicleRelation) RetrieveHyperlinks() (err error) {
+func (avr *AccountVehicleRelation) RetrieveHyperlinks(ctx context.Context) (err error) {
 	if avr.Account == nil {
-		if avr.Account, err = avr.client.CustomerAccount.GetAccountByHyperlink(avr.HyperlinkAccount); err != nil {
+		if avr.Account, err = avr.client.CustomerAccount.GetAccountByHyperlink(ctx, avr.HyperlinkAccount); err != nil {
 			return
 		}
 	}
 	if avr.Vehicle == nil {
-		if avr.Vehicle, err = avr.client.Vehicles.GetVehicleByHyperlink(avr.HyperlinkVehicle); err != nil {
+		if avr.Vehicle, err = avr.client.Vehicles.GetVehicleByHyperlink(ctx, avr.HyperlinkVehicle); err != nil {
 			return
 		}
 	}
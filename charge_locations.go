@@ -1,8 +1,15 @@
 package vocdriver
 
+import (
+	"fmt"
+	"path"
+)
+
+// ChargingLocationPosition is the location of a charging location or a POI. The coordinates are required and always sent, even if
+// one of them is 0 (e.g.: on the prime meridian). The address is optional
 type ChargingLocationPosition struct {
-	Longitude       float64 `json:"longitude,omitempty"`
-	Latitude        float64 `json:"latitude,omitempty"`
+	Longitude       float64 `json:"longitude"`
+	Latitude        float64 `json:"latitude"`
 	StreetAddress   string  `json:"streetAddress,omitempty"`
 	PostalCode      string  `json:"postalCode,omitempty"`
 	City            string  `json:"city,omitempty"`
@@ -10,16 +17,32 @@ type ChargingLocationPosition struct {
 	Region          string  `json:"Region,omitempty"`
 }
 
+// validate returns an error if the coordinates are unset (0, 0) or out of range
+func (p ChargingLocationPosition) validate() error {
+	if p.Latitude == 0 && p.Longitude == 0 {
+		return fmt.Errorf("coordinates must be set")
+	}
+	if p.Latitude < -90 || p.Latitude > 90 || p.Longitude < -180 || p.Longitude > 180 {
+		return fmt.Errorf("coordinates are out of range: %f, %f", p.Latitude, p.Longitude)
+	}
+	return nil
+}
+
 type DelayCharging struct {
-	Enabled   bool   `json:"enabled,omitempty"`
-	StartTime string `json:"startTime,omitempty"` // example value: 21:30
-	StopTime  string `json:"stopTime,omitempty"`  // example value: 06:45
+	Enabled   bool      `json:"enabled"`
+	StartTime ClockTime `json:"startTime"` // example value: 21:30
+	StopTime  ClockTime `json:"stopTime"`  // example value: 06:45
+}
+
+// Window returns the delay charging window, which usually crosses midnight
+func (dc DelayCharging) Window() ClockWindow {
+	return ClockWindow{Start: dc.StartTime, Stop: dc.StopTime}
 }
 
 type ChargingLocation struct {
 	ChargeLocation            string                    `json:"chargeLocation,omitempty"` // Self URL
 	Name                      string                    `json:"name,omitempty"`
-	PlugInReminderEnabled     bool                      `json:"plugInReminderEnabled,omitempty"`
+	PlugInReminderEnabled     bool                      `json:"plugInReminderEnabled"`
 	Position                  *ChargingLocationPosition `json:"position,omitempty"`
 	DelayCharging             *DelayCharging            `json:"delayCharging,omitempty"`
 	Status                    string                    `json:"status,omitempty"`
@@ -27,6 +50,11 @@ type ChargingLocation struct {
 	client                    *Client                   // added for interface simplification
 }
 
+// ID returns the identifier of the location: the last element of its ChargeLocation URL
+func (cl ChargingLocation) ID() string {
+	return path.Base(cl.ChargeLocation)
+}
+
 type ChargingLocations struct {
 	ChargingLocations []ChargingLocation `json:"chargingLocations,omitempty"`
 	client            *Client            // added for interface simplification
@@ -0,0 +1,164 @@
+package vocdriver_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+	"github.com/theriverman/VolvoOnCall/voctest"
+)
+
+func TestChargingLocation_FalseIsSent(t *testing.T) {
+	data, err := json.Marshal(vocdriver.ChargingLocation{DelayCharging: &vocdriver.DelayCharging{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"plugInReminderEnabled":false`) || !strings.Contains(string(data), `"enabled":false`) {
+		t.Errorf("disabled flags must be sent, got %s", data)
+	}
+}
+
+func TestVehicle_ChargingLocationLifecycle(t *testing.T) {
+	_, client, vehicle := newTestVehicle(t, nil)
+	ctx := context.Background()
+
+	cl, err := vehicle.AddChargingLocation(ctx, &vocdriver.ChargingLocation{
+		Name:     "Office",
+		Position: &vocdriver.ChargingLocationPosition{Latitude: 59.404, Longitude: 17.945},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := cl.ID()
+	if id == "" || id == "4075649" {
+		t.Fatalf("unexpected ID of the new location: %q", id)
+	}
+	if _, err = vehicle.RenameChargingLocation(ctx, id, "HQ"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = vehicle.MoveChargingLocation(ctx, id, &vocdriver.ChargingLocationPosition{Latitude: 59.33, Longitude: 18.07}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = vehicle.SetPlugInReminder(ctx, id, true); err != nil {
+		t.Fatal(err)
+	}
+	cl, err = client.Vehicles.GetChargingLocation(ctx, voctest.VIN, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cl.Name != "HQ" || cl.Position.Latitude != 59.33 || cl.Position.Longitude != 18.07 || !cl.PlugInReminderEnabled {
+		t.Errorf("unexpected location: %+v %+v", cl, cl.Position)
+	}
+
+	if _, err = vehicle.SetPlugInReminder(ctx, "4075649", false); err != nil {
+		t.Fatal(err)
+	}
+	if err = vehicle.RemoveChargingLocation(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	locations, err := client.Vehicles.GetChargingLocations(ctx, voctest.VIN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locations.ChargingLocations) != 1 || locations.ChargingLocations[0].PlugInReminderEnabled {
+		t.Errorf("unexpected locations: %+v", locations.ChargingLocations)
+	}
+}
+
+func TestVehicle_ChargingLocationOnPrimeMeridian(t *testing.T) {
+	srv, client, vehicle := newTestVehicle(t, nil)
+	ctx := context.Background()
+
+	cl, err := vehicle.AddChargingLocation(ctx, &vocdriver.ChargingLocation{
+		Name:     "Greenwich",
+		Position: &vocdriver.ChargingLocationPosition{Latitude: 51.4779, Longitude: 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = vehicle.MoveChargingLocation(ctx, cl.ID(), &vocdriver.ChargingLocationPosition{Latitude: 51.5, Longitude: 0}); err != nil {
+		t.Fatal(err)
+	}
+	sent := 0
+	for _, r := range srv.Handler.Requests() {
+		if (r.Method == "POST" || r.Method == "PUT") && strings.Contains(r.Path, "chargeLocations") {
+			if sent++; !strings.Contains(string(r.Body), `"longitude":0`) {
+				t.Errorf("a zero longitude must be sent, got %s %s %s", r.Method, r.Path, r.Body)
+			}
+		}
+	}
+	if sent != 2 {
+		t.Errorf("expected a POST and a PUT of the location, got %d", sent)
+	}
+
+	if _, err = vehicle.AddChargingLocation(ctx, nil); err == nil {
+		t.Error("expected a nil location to be rejected")
+	}
+	if _, err = vehicle.AddChargingLocation(ctx, &vocdriver.ChargingLocation{Name: "Nowhere", Position: &vocdriver.ChargingLocationPosition{}}); err == nil {
+		t.Error("expected a location without coordinates to be rejected")
+	}
+	if _, err = vehicle.MoveChargingLocation(ctx, cl.ID(), &vocdriver.ChargingLocationPosition{City: "London"}); err == nil {
+		t.Error("expected a move without coordinates to be rejected")
+	}
+	if _, err = client.Vehicles.CreateChargingLocation(ctx, voctest.VIN, &vocdriver.ChargingLocation{Name: "Nowhere", Position: &vocdriver.ChargingLocationPosition{Latitude: 91}}); err == nil {
+		t.Error("expected out of range coordinates to be rejected")
+	}
+}
+
+func TestVehicle_SetDelayCharging(t *testing.T) {
+	_, client, vehicle := newTestVehicle(t, nil)
+	ctx := context.Background()
+	second, err := vehicle.AddChargingLocation(ctx, &vocdriver.ChargingLocation{
+		Name:     "Cabin",
+		Position: &vocdriver.ChargingLocationPosition{Latitude: 63.4, Longitude: 13.08},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the default vehicle allows a single location with delay charging, which Home already uses
+	if _, err = vehicle.SetDelayCharging(ctx, second.ID(), &vocdriver.DelayCharging{Enabled: true, StartTime: vocdriver.NewClockTime(23, 0), StopTime: vocdriver.NewClockTime(5, 0)}); !errors.Is(err, vocdriver.ErrLimitExceeded) {
+		t.Errorf("expected ErrLimitExceeded, got %v", err)
+	}
+	if _, err = vehicle.SetDelayCharging(ctx, "4075649", &vocdriver.DelayCharging{Enabled: false}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = vehicle.SetDelayCharging(ctx, second.ID(), &vocdriver.DelayCharging{Enabled: true, StartTime: vocdriver.NewClockTime(23, 0), StopTime: vocdriver.NewClockTime(5, 0)}); err != nil {
+		t.Fatal(err)
+	}
+
+	home, err := client.Vehicles.GetChargingLocation(ctx, voctest.VIN, "4075649")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *home.DelayCharging != (vocdriver.DelayCharging{StartTime: vocdriver.NewClockTime(22, 0), StopTime: vocdriver.NewClockTime(6, 0)}) || home.Name != "Home" || !home.PlugInReminderEnabled {
+		t.Errorf("disabling delay charging should keep the rest of the location, got %+v %+v", home, home.DelayCharging)
+	}
+}
+
+func TestVehicle_OverrideDelayCharging(t *testing.T) {
+	srv, _, vehicle := newTestVehicle(t, nil, vocdriver.WithPollPolicy(&vocdriver.PollPolicy{InitialInterval: 50 * time.Millisecond, Timeout: 5 * time.Second}))
+	ctx := context.Background()
+	op, err := vehicle.OverrideDelayCharging(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = op.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if st := op.Status(); st.ServiceType != "overrideDelayCharging" {
+		t.Errorf("unexpected service type %q", st.ServiceType)
+	}
+	if v, _ := srv.Handler.Vehicle(voctest.VIN); v.Status.HvBattery.HvBatteryChargeStatusDerived != "CablePluggedInCar_Charging" {
+		t.Errorf("expected the car to be charging, got %s", v.Status.HvBattery.HvBatteryChargeStatusDerived)
+	}
+
+	vehicle.Attributes.OverrideDelayChargingSupported = false
+	if _, err = vehicle.OverrideDelayCharging(ctx); !errors.Is(err, vocdriver.ErrVehicleUnsupported) {
+		t.Errorf("expected ErrVehicleUnsupported, got %v", err)
+	}
+}
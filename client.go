@@ -1,41 +1,90 @@
 package vocdriver
 
 import (
+	"context"
 	"encoding/base64"
-	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync"
 )
 
-/*
-Possible ServiceRegion options:
-- Africa
-- Asian (except China Mainland)
-- China Mainland
-- Europe [default]
-- North America
-- Oceania
-- South America
-*/
-
+// BaseUrl is the API URL template of the service regions. See Region.BaseURL
 const BaseUrl string = "https://vocapi%s.wirelesscar.net/customerapi/rest/v3.0"
 
-func NewClient(username, password string) (*Client, error) {
-	client := Client{}
-	client.Initialise()
-	client.Authenticate(username, password)
-	return &client, nil
+// NewClient returns an initialised Client configured by the given options
+//
+// The combination of options is validated up front, e.g.:
+//
+//	client, err := vocdriver.NewClient(
+//		vocdriver.WithCredentials("your-volvo-on-call-username", "your password"),
+//		vocdriver.WithRegion(vocdriver.RegionNorthAmerica),
+//		vocdriver.WithTimeout(30*time.Second),
+//	)
+//
+// NewClient detects a RegionAuto region without a deadline. Use NewClientContext to cancel or time out the detection
+func NewClient(opts ...Option) (*Client, error) {
+	return NewClientContext(context.Background(), opts...)
+}
+
+// NewClientContext works like NewClient. ctx bounds the requests detecting a RegionAuto region
+func NewClientContext(ctx context.Context, opts ...Option) (*Client, error) {
+	o := &clientOptions{}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+
+	client := &Client{
+		BaseURL:          o.baseURL,
+		ServiceRegion:    o.region,
+		HTTPClient:       o.httpClient,
+		RetryPolicy:      o.retryPolicy,
+		PollPolicy:       o.pollPolicy,
+		Logger:           o.logger,
+		Credentials:      o.credentials,
+		UserAgentProfile: o.userAgentProfile,
+	}
+	if o.timeout > 0 {
+		client.HTTPClient = &http.Client{Timeout: o.timeout}
+	}
+	if err := client.Initialise(); err != nil {
+		return nil, err
+	}
+	client.LoadExternalHeaders(o.headers)
+	if o.region == RegionAuto {
+		if _, err := client.DetectRegion(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return client, nil
 }
 
 type Client struct {
 	apiUrl        string
 	BaseURL       string
-	ServiceRegion string
+	ServiceRegion Region // ignored if BaseURL is set
 	Headers       *http.Header
-	HTTPClient    *http.Client
+	HTTPClient    *http.Client        // a default http.Client is created by Initialise if nil
+	RetryPolicy   *RetryPolicy        // nil disables retries. See DefaultRetryPolicy
+	PollPolicy    *PollPolicy         // used to evaluate remote commands. DefaultPollPolicy is used if nil
+	Credentials   CredentialsProvider // resolved before every request to set the Authorization header. See Authenticate
+
+	// UserAgentProfile describes the app the requests appear to come from. DefaultUserAgentProfile is used if empty
+	UserAgentProfile UserAgentProfile
 
 	isInitialised bool
-	Verbose       bool
+	Verbose       bool   // logs every event to stderr if Logger is nil
+	Logger        Logger // receives structured events about requests, responses and service status polls
+	verboseLogger Logger
+	verboseOnce   sync.Once // creates verboseLogger once, as the Client is used by concurrent Operations
+
+	attributesMu sync.Mutex
+	attributes   map[string]*VehicleAttributes // by VIN, see rememberAttributes
 
 	// Core Services
 	Request *RequestService
@@ -52,28 +101,34 @@ func (c *Client) Initialise() error {
 		return nil
 	}
 
-	// add a default http.Client{}
-	c.HTTPClient = &http.Client{
-		// CheckRedirect: redirectPolicyFunc,
+	// add a default http.Client{} unless one was provided
+	if c.HTTPClient == nil {
+		c.HTTPClient = &http.Client{
+			// CheckRedirect: redirectPolicyFunc,
+		}
 	}
 
 	// set basic headers
+	profile := c.UserAgentProfile
+	if profile == (UserAgentProfile{}) {
+		profile = DefaultUserAgentProfile
+	}
 	c.Headers = &http.Header{}
 	c.Headers.Add("Content-Type", "application/json")
-	c.Headers.Add("X-App-Name", "Volvo On Call")
-	c.Headers.Add("X-Client-Version", "4.4.5.21126")
-	c.Headers.Add("X-Device-Id", "Device")
-	c.Headers.Add("X-Originator-Type", "App")
-	c.Headers.Add("X-OS-Type", "Android")
-	c.Headers.Add("X-OS-Version", "22")
-
-	switch {
-	case c.BaseURL == "" && c.ServiceRegion == "":
-		c.apiUrl = fmt.Sprintf(BaseUrl, c.ServiceRegion)
-	case c.BaseURL == "" && c.ServiceRegion != "":
-		c.apiUrl = fmt.Sprintf(c.BaseURL, "-"+c.ServiceRegion)
-	case c.BaseURL != "":
-		c.apiUrl = c.BaseURL // ServiceRegion must be defined as part of the BaseUrl
+	c.Headers.Add("X-App-Name", profile.AppName)
+	c.Headers.Add("X-Client-Version", profile.ClientVersion)
+	c.Headers.Add("X-Device-Id", profile.DeviceID)
+	c.Headers.Add("X-Originator-Type", profile.OriginatorType)
+	c.Headers.Add("X-OS-Type", profile.OSType)
+	c.Headers.Add("X-OS-Version", profile.OSVersion)
+
+	if c.BaseURL == "" {
+		c.apiUrl = c.ServiceRegion.BaseURL()
+	} else {
+		if err := validateBaseURL(c.BaseURL); err != nil {
+			return err
+		}
+		c.apiUrl = strings.TrimSuffix(c.BaseURL, "/") // ServiceRegion must be defined as part of the BaseUrl
 	}
 
 	// Bootstrapping Services
@@ -92,9 +147,9 @@ func (c *Client) LoadExternalHeaders(headers map[string]string) {
 	}
 }
 
-// Authenticate encodes username+password using base64 and adds the resulting string to default Headers
+// Authenticate sets StaticCredentials as the CredentialsProvider of the Client
 func (c *Client) Authenticate(username, password string) {
-	c.Headers.Add("Authorization", "Basic "+basicAuth(username, password))
+	c.Credentials = StaticCredentials{Username: username, Password: password}
 }
 
 // MakeURL accepts an Endpoint URL and returns a compiled absolute URL
@@ -108,12 +163,26 @@ func (c *Client) MakeURL(EndpointParts ...string) string {
 	return c.apiUrl + "/" + strings.Join(EndpointParts, "/")
 }
 
-func (c *Client) EvaluateServiceStatus(vss *VehicleServiceStatus, timeoutSeconds int) (err error) {
-	return c.Vehicles.EvaluateServiceStatus(vss, timeoutSeconds)
+// MakeURLWithQuery works like MakeURL and appends the encoded query to the URL unless it is empty
+//
+//	For example:
+//	- If the given endpoint URLs are [vehicles, YV1ABCDEFGH123456, trips]
+//	- If the query is {limit: [10]}
+//	- It returns https://vocapi.wirelesscar.net/customerapi/rest/v3.0/vehicles/YV1ABCDEFGH123456/trips?limit=10
+func (c *Client) MakeURLWithQuery(query url.Values, EndpointParts ...string) string {
+	u := c.MakeURL(EndpointParts...)
+	if len(query) == 0 {
+		return u
+	}
+	return u + "?" + query.Encode()
+}
+
+func (c *Client) EvaluateServiceStatus(ctx context.Context, vss *VehicleServiceStatus, policy *PollPolicy) (err error) {
+	return c.Vehicles.EvaluateServiceStatus(ctx, vss, policy)
 }
 
-func (c *Client) EvaluateServiceStatusAuto(vss *VehicleServiceStatus) (err error) {
-	return c.Vehicles.EvaluateServiceStatusAuto(vss)
+func (c *Client) EvaluateServiceStatusAuto(ctx context.Context, vss *VehicleServiceStatus) (err error) {
+	return c.Vehicles.EvaluateServiceStatusAuto(ctx, vss)
 }
 
 func basicAuth(username, password string) string {
@@ -1,51 +1,62 @@
-package vocdriver
+package vocdriver_test
 
 import (
-	"log"
-	"os"
+	"context"
 	"testing"
 
-	"github.com/joho/godotenv"
+	vocdriver "github.com/theriverman/VolvoOnCall"
+	"github.com/theriverman/VolvoOnCall/voctest"
 )
 
 func TestClient_Initialise(t *testing.T) {
-	err := godotenv.Load("/workspaces/VolvoOnCall/.env")
-	if err != nil {
-		log.Fatal("Error loading .env file")
-	}
+	srv := voctest.NewServer(nil)
+	defer srv.Close()
 
-	username := os.Getenv("username")
-	password := os.Getenv("password")
+	ctx := context.Background()
 
-	client, err := NewClient(username, password)
+	client, err := srv.NewClient()
 	if err != nil {
 		t.Fatalf("%v\n", err)
 	}
 
-	account, err := client.CustomerAccount.GetAccount()
+	account, err := client.CustomerAccount.GetAccount(ctx)
 	if err != nil {
 		t.Fatalf("%v\n", err)
 	}
 
-	vehicles, err := account.GetVehicles()
+	vehicles, err := account.GetVehicles(ctx)
 	if err != nil {
 		t.Fatalf("%v\n", err)
 	}
+	if len(vehicles) != 1 {
+		t.Fatalf("expected 1 vehicle, got %d", len(vehicles))
+	}
 
 	t.Logf("My Vehicles:\n")
 	for _, vehicle := range vehicles {
-		if err = vehicle.RetrieveHyperlinks(); err != nil {
+		if err = vehicle.RetrieveHyperlinks(ctx); err != nil {
 			t.Errorf("%v\n", err)
 			continue
 		}
 		t.Logf("  * %s (%s)\n", vehicle.VehicleID, vehicle.Attributes.RegistrationNumber)
 		t.Logf("    - IsHeaterSupported: %t\n", vehicle.IsHeaterSupported())
-		status, err := vehicle.BlinkLights(nil)
+		if vehicle.VehicleID != voctest.VIN || vehicle.Attributes.RegistrationNumber != "ABC123" || len(vehicle.VehicleAccountRelations) != 1 {
+			t.Errorf("unexpected vehicle: %+v", vehicle)
+		}
+		op, err := vehicle.BlinkLights(ctx, nil)
 		if err != nil {
 			t.Fatalf("%v", err)
 		}
-		if err = client.Vehicles.EvaluateServiceStatusAuto(status); err != nil {
+		if err = op.Wait(ctx); err != nil {
 			t.Fatalf("%v", err)
 		}
 	}
 }
+
+func TestClient_Unauthorized(t *testing.T) {
+	_, client := newTestClient(t, nil)
+	client.Authenticate(voctest.Username, "invalid")
+	if _, err := client.CustomerAccount.GetAccount(context.Background()); !vocdriver.IsUnauthorized(err) {
+		t.Fatalf("expected an unauthorized error, got %v", err)
+	}
+}
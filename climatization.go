@@ -0,0 +1,264 @@
+package vocdriver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Weekdays is a set of days of the week
+type Weekdays uint8
+
+const (
+	WorkingDays Weekdays = 1<<time.Monday | 1<<time.Tuesday | 1<<time.Wednesday | 1<<time.Thursday | 1<<time.Friday
+	Weekend     Weekdays = 1<<time.Saturday | 1<<time.Sunday
+	EveryDay    Weekdays = WorkingDays | Weekend
+)
+
+// NewWeekdays returns the set of days
+func NewWeekdays(days ...time.Weekday) (w Weekdays) {
+	for _, d := range days {
+		w |= 1 << d
+	}
+	return w
+}
+
+// ParseWeekdays parses a comma separated list of day names or abbreviations (e.g.: mon,wed,friday)
+// as well as workdays, weekend and everyday
+func ParseWeekdays(s string) (w Weekdays, err error) {
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "workdays", "weekdays":
+			w |= WorkingDays
+			continue
+		case "weekend":
+			w |= Weekend
+			continue
+		case "everyday", "daily":
+			w |= EveryDay
+			continue
+		}
+		d, ok := parseWeekday(name)
+		if !ok {
+			return 0, fmt.Errorf("unknown day of the week %q", name)
+		}
+		w |= NewWeekdays(d)
+	}
+	return w, nil
+}
+
+// parseWeekday accepts English day names and their abbreviations of at least three letters
+func parseWeekday(name string) (time.Weekday, bool) {
+	if len(name) < 3 {
+		return 0, false
+	}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.HasPrefix(strings.ToLower(d.String()), name) {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// Contains returns true if d is part of the set
+func (w Weekdays) Contains(d time.Weekday) bool {
+	return w&(1<<d) != 0
+}
+
+// Days returns the days of the set starting with Monday
+func (w Weekdays) Days() []time.Weekday {
+	days := []time.Weekday{}
+	for i := 1; i <= 7; i++ {
+		if d := time.Weekday(i % 7); w.Contains(d) {
+			days = append(days, d)
+		}
+	}
+	return days
+}
+
+func (w Weekdays) String() string {
+	switch w {
+	case EveryDay:
+		return "every day"
+	case WorkingDays:
+		return "workdays"
+	case Weekend:
+		return "weekend"
+	}
+	names := []string{}
+	for _, d := range w.Days() {
+		names = append(names, d.String()[:3])
+	}
+	return strings.Join(names, ",")
+}
+
+// MarshalJSON encodes the set as a list of lowercase day names, e.g.: ["monday","friday"]
+func (w Weekdays) MarshalJSON() ([]byte, error) {
+	names := []string{}
+	for _, d := range w.Days() {
+		names = append(names, strings.ToLower(d.String()))
+	}
+	return json.Marshal(names)
+}
+
+func (w *Weekdays) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+	*w = 0
+	for _, name := range names {
+		d, ok := parseWeekday(strings.ToLower(name))
+		if !ok {
+			return fmt.Errorf("unknown day of the week %q", name)
+		}
+		*w |= NewWeekdays(d)
+	}
+	return nil
+}
+
+// ClimatizationTimer preconditions the car to be ready for departure at Time on every day of Weekdays while Enabled
+type ClimatizationTimer struct {
+	ID       int       `json:"id"`
+	Weekdays Weekdays  `json:"weekdays"`
+	Time     ClockTime `json:"time"` // departure time, example value: 07:30
+	Enabled  bool      `json:"enabled"`
+}
+
+// validate returns an error if the timer has no day or Time is unset
+func (ct ClimatizationTimer) validate() error {
+	if ct.Weekdays&EveryDay == 0 {
+		return fmt.Errorf("invalid climatization timer %d: weekdays must not be empty", ct.ID)
+	}
+	if ct.Time.IsZero() {
+		return fmt.Errorf("invalid climatization timer %d: time must be set", ct.ID)
+	}
+	return nil
+}
+
+// ClimatizationCalendar holds the recurring departure timers of the car
+type ClimatizationCalendar struct {
+	Timers []ClimatizationTimer `json:"timers"`
+}
+
+// Timer returns the timer identified by id, or nil
+func (cc *ClimatizationCalendar) Timer(id int) *ClimatizationTimer {
+	for i := range cc.Timers {
+		if cc.Timers[i].ID == id {
+			return &cc.Timers[i]
+		}
+	}
+	return nil
+}
+
+func (v *VehiclesService) GetClimatizationCalendar(ctx context.Context, vin string) (calendar *ClimatizationCalendar, err error) {
+	if vin == "" {
+		return nil, fmt.Errorf("vin must not be empty")
+	}
+	url := v.client.MakeURL(v.Endpoint, vin, "climatizationCalendar")
+	if _, err = v.client.Request.Get(ctx, url, &calendar); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// UpdateClimatizationCalendar replaces every timer of the calendar
+func (v *VehiclesService) UpdateClimatizationCalendar(ctx context.Context, vin string, calendar *ClimatizationCalendar) (calendarResponse *ClimatizationCalendar, err error) {
+	if vin == "" {
+		return nil, fmt.Errorf("vin must not be empty")
+	}
+	if calendar == nil {
+		return nil, fmt.Errorf("climatization calendar must not be empty")
+	}
+	for _, ct := range calendar.Timers {
+		if err = ct.validate(); err != nil {
+			return nil, err
+		}
+	}
+	url := v.client.MakeURL(v.Endpoint, vin, "climatizationCalendar")
+	if _, err = v.client.Request.Put(ctx, url, calendar, &calendarResponse); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (v Vehicle) IsClimatizationCalendarSupported() bool {
+	return len(v.Attributes.ClimatizationCalendarVersionsSupported) > 0
+}
+
+// GetClimatizationCalendar returns the current departure timers of the car
+func (v Vehicle) GetClimatizationCalendar(ctx context.Context) (*ClimatizationCalendar, error) {
+	if !v.IsClimatizationCalendarSupported() {
+		return nil, fmt.Errorf("climatization calendar is not supported by %s [%s]: %w", v.Attributes.RegistrationNumber, v.Attributes.Vin, ErrVehicleUnsupported)
+	}
+	return v.client.Vehicles.GetClimatizationCalendar(ctx, v.VehicleID)
+}
+
+// AddClimatizationTimer adds timer to the calendar with the next free ID, unless the calendar already
+// holds ClimatizationCalendarMaxTimers timers, in which case an error wrapping ErrLimitExceeded is returned
+func (v Vehicle) AddClimatizationTimer(ctx context.Context, timer ClimatizationTimer) (*ClimatizationTimer, error) {
+	var added ClimatizationTimer
+	_, err := v.updateClimatizationCalendar(ctx, func(cc *ClimatizationCalendar) error {
+		if limit := v.Attributes.ClimatizationCalendarMaxTimers; limit > 0 && len(cc.Timers) >= limit {
+			return fmt.Errorf("climatization calendar of %s [%s] is limited to %d timers: %w", v.Attributes.RegistrationNumber, v.Attributes.Vin, limit, ErrLimitExceeded)
+		}
+		timer.ID = 1
+		for _, ct := range cc.Timers {
+			if ct.ID >= timer.ID {
+				timer.ID = ct.ID + 1
+			}
+		}
+		cc.Timers = append(cc.Timers, timer)
+		added = timer
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &added, nil
+}
+
+// UpdateClimatizationTimer replaces the timer of the calendar with the ID of timer
+func (v Vehicle) UpdateClimatizationTimer(ctx context.Context, timer ClimatizationTimer) (*ClimatizationTimer, error) {
+	_, err := v.updateClimatizationCalendar(ctx, func(cc *ClimatizationCalendar) error {
+		ct := cc.Timer(timer.ID)
+		if ct == nil {
+			return fmt.Errorf("climatization timer %d was not found", timer.ID)
+		}
+		*ct = timer
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &timer, nil
+}
+
+// DeleteClimatizationTimer removes the timer identified by id from the calendar
+func (v Vehicle) DeleteClimatizationTimer(ctx context.Context, id int) error {
+	_, err := v.updateClimatizationCalendar(ctx, func(cc *ClimatizationCalendar) error {
+		for i, ct := range cc.Timers {
+			if ct.ID == id {
+				cc.Timers = append(cc.Timers[:i], cc.Timers[i+1:]...)
+				return nil
+			}
+		}
+		return fmt.Errorf("climatization timer %d was not found", id)
+	})
+	return err
+}
+
+// updateClimatizationCalendar retrieves the calendar, applies update and writes the whole calendar back
+func (v Vehicle) updateClimatizationCalendar(ctx context.Context, update func(cc *ClimatizationCalendar) error) (*ClimatizationCalendar, error) {
+	cc, err := v.GetClimatizationCalendar(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = update(cc); err != nil {
+		return nil, err
+	}
+	return v.client.Vehicles.UpdateClimatizationCalendar(ctx, v.VehicleID, cc)
+}
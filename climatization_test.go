@@ -0,0 +1,105 @@
+package vocdriver_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+	"github.com/theriverman/VolvoOnCall/voctest"
+)
+
+func TestParseWeekdays(t *testing.T) {
+	for s, expected := range map[string]vocdriver.Weekdays{
+		"mon,Wed, friday": vocdriver.NewWeekdays(time.Monday, time.Wednesday, time.Friday),
+		"workdays":        vocdriver.WorkingDays,
+		"weekend,mon":     vocdriver.Weekend | vocdriver.NewWeekdays(time.Monday),
+		"everyday":        vocdriver.EveryDay,
+	} {
+		w, err := vocdriver.ParseWeekdays(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if w != expected {
+			t.Errorf("%s: expected %s, got %s", s, expected, w)
+		}
+	}
+	for _, invalid := range []string{"", "mo", "someday", "mon,,tue"} {
+		if _, err := vocdriver.ParseWeekdays(invalid); err == nil {
+			t.Errorf("expected %q to be rejected", invalid)
+		}
+	}
+}
+
+func TestWeekdays_JSON(t *testing.T) {
+	w := vocdriver.NewWeekdays(time.Sunday, time.Tuesday)
+	data, err := json.Marshal(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `["tuesday","sunday"]` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+	var decoded vocdriver.Weekdays
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != w || decoded.String() != "Tue,Sun" {
+		t.Errorf("unexpected weekdays: %s", decoded)
+	}
+}
+
+func TestVehicle_ClimatizationCalendar(t *testing.T) {
+	fixtures := voctest.DefaultFixtures()
+	fixtures.Vehicles[0].Attributes.ClimatizationCalendarMaxTimers = 2
+	_, client, vehicle := newTestVehicle(t, fixtures)
+	ctx := context.Background()
+
+	added, err := vehicle.AddClimatizationTimer(ctx, vocdriver.ClimatizationTimer{Weekdays: vocdriver.Weekend, Time: vocdriver.NewClockTime(9, 30), Enabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added.ID != 2 {
+		t.Errorf("expected the next free ID, got %d", added.ID)
+	}
+	if _, err = vehicle.AddClimatizationTimer(ctx, vocdriver.ClimatizationTimer{Weekdays: vocdriver.Weekend, Time: vocdriver.NewClockTime(10, 30)}); !errors.Is(err, vocdriver.ErrLimitExceeded) {
+		t.Errorf("expected ErrLimitExceeded, got %v", err)
+	}
+
+	added.Enabled = false
+	if _, err = vehicle.UpdateClimatizationTimer(ctx, *added); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = vehicle.UpdateClimatizationTimer(ctx, vocdriver.ClimatizationTimer{ID: 1, Weekdays: vocdriver.WorkingDays}); err == nil {
+		t.Error("expected a timer without time to be rejected")
+	}
+	if _, err = vehicle.UpdateClimatizationTimer(ctx, vocdriver.ClimatizationTimer{ID: 42, Weekdays: vocdriver.WorkingDays, Time: vocdriver.NewClockTime(7, 15)}); err == nil {
+		t.Error("expected an unknown timer to be rejected")
+	}
+	if _, err = client.Vehicles.UpdateClimatizationCalendar(ctx, voctest.VIN, nil); err == nil {
+		t.Error("expected a nil calendar to be rejected")
+	}
+	if err = vehicle.DeleteClimatizationTimer(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	calendar, err := vehicle.GetClimatizationCalendar(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calendar.Timers) != 1 || calendar.Timers[0] != (vocdriver.ClimatizationTimer{ID: 2, Weekdays: vocdriver.Weekend, Time: vocdriver.NewClockTime(9, 30)}) {
+		t.Errorf("unexpected timers: %+v", calendar.Timers)
+	}
+}
+
+func TestVehicle_ClimatizationCalendarUnsupported(t *testing.T) {
+	fixtures := voctest.DefaultFixtures()
+	fixtures.Vehicles[0].Attributes.ClimatizationCalendarVersionsSupported = nil
+	_, _, vehicle := newTestVehicle(t, fixtures)
+	ctx := context.Background()
+	if err := vehicle.DeleteClimatizationTimer(ctx, 1); !errors.Is(err, vocdriver.ErrVehicleUnsupported) {
+		t.Errorf("expected ErrVehicleUnsupported, got %v", err)
+	}
+}
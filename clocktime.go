@@ -0,0 +1,151 @@
+package vocdriver
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const minutesPerDay = 24 * 60
+
+// ClockTime is a time of day with minute precision (e.g.: the start of a delay charging window),
+// encoded as HH:MM by the VOC API. The zero value is unset and encoded as null
+type ClockTime struct {
+	minutes int // minutes since midnight + 1 so 00:00 is distinct from the zero value
+}
+
+// NewClockTime returns the time of day hour:minute. Values out of range are normalized like in time.Date, e.g.: 24:30 is 00:30
+func NewClockTime(hour, minute int) ClockTime {
+	m := (hour*60 + minute) % minutesPerDay
+	if m < 0 {
+		m += minutesPerDay
+	}
+	return ClockTime{minutes: m + 1}
+}
+
+// ClockTimeOf returns the time of day of t in its location
+func ClockTimeOf(t time.Time) ClockTime {
+	return NewClockTime(t.Hour(), t.Minute())
+}
+
+// ParseClockTime parses a time of day formatted as HH:MM (e.g.: 06:45). The hour may have a single digit
+func ParseClockTime(s string) (ClockTime, error) {
+	hh, mm, ok := strings.Cut(strings.TrimSpace(s), ":")
+	hour, errHour := strconv.Atoi(hh)
+	minute, errMinute := strconv.Atoi(mm)
+	if !ok || errHour != nil || errMinute != nil || len(hh) < 1 || len(hh) > 2 || len(mm) != 2 || !isDigits(hh+mm) ||
+		hour > 23 || minute > 59 {
+		return ClockTime{}, fmt.Errorf("time must be formatted as HH:MM, got %q", s)
+	}
+	return NewClockTime(hour, minute), nil
+}
+
+// isDigits returns true if s consists of ASCII digits only, e.g.: no sign accepted by strconv.Atoi
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// IsZero returns true if the time is unset
+func (t ClockTime) IsZero() bool {
+	return t.minutes == 0
+}
+
+// Hour returns the hour within the day, in the range [0, 23]
+func (t ClockTime) Hour() int { return t.sinceMidnight() / 60 }
+
+// Minute returns the minute offset within the hour, in the range [0, 59]
+func (t ClockTime) Minute() int { return t.sinceMidnight() % 60 }
+
+// sinceMidnight returns the minutes elapsed since midnight. Unset times are considered midnight
+func (t ClockTime) sinceMidnight() int {
+	if t.IsZero() {
+		return 0
+	}
+	return t.minutes - 1
+}
+
+// String returns the time formatted as HH:MM, or an empty string if it is unset
+func (t ClockTime) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%02d:%02d", t.Hour(), t.Minute())
+}
+
+// Next returns the first occurrence of the time of day in loc at or after from
+func (t ClockTime) Next(from time.Time, loc *time.Location) time.Time {
+	from = from.In(loc)
+	next := time.Date(from.Year(), from.Month(), from.Day(), t.Hour(), t.Minute(), 0, 0, loc)
+	if next.Before(from) {
+		next = time.Date(from.Year(), from.Month(), from.Day()+1, t.Hour(), t.Minute(), 0, 0, loc)
+	}
+	return next
+}
+
+func (t ClockTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON leaves the time unset for null and empty strings
+func (t *ClockTime) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		*t = ClockTime{}
+		return nil
+	}
+	parsed, err := ParseClockTime(s)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// ClockWindow is the time from Start until Stop. It crosses midnight if Stop is before Start, e.g.: 22:00 - 06:00
+type ClockWindow struct {
+	Start ClockTime
+	Stop  ClockTime
+}
+
+// CrossesMidnight returns true if the window ends on the day after it starts
+func (w ClockWindow) CrossesMidnight() bool {
+	return w.Stop.sinceMidnight() < w.Start.sinceMidnight()
+}
+
+// Contains returns true if t is within the window. Start is inclusive, Stop is exclusive
+func (w ClockWindow) Contains(t ClockTime) bool {
+	start, stop, m := w.Start.sinceMidnight(), w.Stop.sinceMidnight(), t.sinceMidnight()
+	if w.CrossesMidnight() {
+		return m >= start || m < stop
+	}
+	return m >= start && m < stop
+}
+
+// Duration returns the length of the window
+func (w ClockWindow) Duration() time.Duration {
+	d := w.Stop.sinceMidnight() - w.Start.sinceMidnight()
+	if d < 0 {
+		d += minutesPerDay
+	}
+	return time.Duration(d) * time.Minute
+}
+
+func (w ClockWindow) String() string {
+	return w.Start.String() + " - " + w.Stop.String()
+}
@@ -0,0 +1,96 @@
+package vocdriver_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+)
+
+func TestParseClockTime(t *testing.T) {
+	for s, expected := range map[string]vocdriver.ClockTime{
+		"06:45": vocdriver.NewClockTime(6, 45),
+		"6:45":  vocdriver.NewClockTime(6, 45),
+		"00:00": vocdriver.NewClockTime(0, 0),
+		"23:59": vocdriver.NewClockTime(23, 59),
+	} {
+		ct, err := vocdriver.ParseClockTime(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ct != expected || ct.IsZero() {
+			t.Errorf("%s: expected %s, got %s", s, expected, ct)
+		}
+	}
+	for _, invalid := range []string{"", "24:00", "12:60", "12:5", "1245", "06:45:00", "-1:30", "ab:cd", "+1:30", "1:+5", "-0:30", "12:-0", "06: 45"} {
+		if _, err := vocdriver.ParseClockTime(invalid); err == nil {
+			t.Errorf("expected %q to be rejected", invalid)
+		}
+	}
+	if ct := vocdriver.NewClockTime(24, 30); ct.String() != "00:30" {
+		t.Errorf("expected out of range values to be normalized, got %s", ct)
+	}
+}
+
+func TestClockTime_JSON(t *testing.T) {
+	var dc vocdriver.DelayCharging
+	if err := json.Unmarshal([]byte(`{"enabled":true,"startTime":"21:30","stopTime":null}`), &dc); err != nil {
+		t.Fatal(err)
+	}
+	if dc.StartTime != vocdriver.NewClockTime(21, 30) || !dc.StopTime.IsZero() {
+		t.Errorf("unexpected times: %s / %s", dc.StartTime, dc.StopTime)
+	}
+	data, err := json.Marshal(dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"enabled":true,"startTime":"21:30","stopTime":null}` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+	if err = json.Unmarshal([]byte(`{"startTime":"25:00"}`), &dc); err == nil {
+		t.Error("expected an invalid time to be rejected")
+	}
+}
+
+func TestClockWindow(t *testing.T) {
+	overnight := vocdriver.ClockWindow{Start: vocdriver.NewClockTime(22, 0), Stop: vocdriver.NewClockTime(6, 0)}
+	if !overnight.CrossesMidnight() || overnight.Duration() != 8*time.Hour {
+		t.Errorf("unexpected window %s: crosses midnight %t, duration %s", overnight, overnight.CrossesMidnight(), overnight.Duration())
+	}
+	for ct, expected := range map[vocdriver.ClockTime]bool{
+		vocdriver.NewClockTime(21, 59): false,
+		vocdriver.NewClockTime(22, 0):  true,
+		vocdriver.NewClockTime(0, 0):   true,
+		vocdriver.NewClockTime(5, 59):  true,
+		vocdriver.NewClockTime(6, 0):   false,
+		vocdriver.NewClockTime(12, 0):  false,
+	} {
+		if overnight.Contains(ct) != expected {
+			t.Errorf("%s contains %s: expected %t", overnight, ct, expected)
+		}
+	}
+
+	daytime := vocdriver.ClockWindow{Start: vocdriver.NewClockTime(9, 0), Stop: vocdriver.NewClockTime(17, 30)}
+	if daytime.CrossesMidnight() || daytime.Duration() != 8*time.Hour+30*time.Minute || !daytime.Contains(vocdriver.NewClockTime(12, 0)) || daytime.Contains(vocdriver.NewClockTime(18, 0)) {
+		t.Errorf("unexpected daytime window %s", daytime)
+	}
+}
+
+func TestClockTime_Next(t *testing.T) {
+	cet := time.FixedZone("CET", 3600)
+	from := time.Date(2022, 11, 20, 21, 0, 0, 0, time.UTC) // 22:00 CET
+
+	if next := vocdriver.NewClockTime(22, 0).Next(from, cet); !next.Equal(from) {
+		t.Errorf("expected the current minute to be the next occurrence, got %s", next)
+	}
+	if next := vocdriver.NewClockTime(23, 30).Next(from, cet); !next.Equal(time.Date(2022, 11, 20, 23, 30, 0, 0, cet)) {
+		t.Errorf("expected later the same day, got %s", next)
+	}
+	if next := vocdriver.NewClockTime(6, 0).Next(from, cet); !next.Equal(time.Date(2022, 11, 21, 6, 0, 0, 0, cet)) {
+		t.Errorf("expected the next day, got %s", next)
+	}
+	if ct := vocdriver.ClockTimeOf(from.In(cet)); ct != vocdriver.NewClockTime(22, 0) {
+		t.Errorf("unexpected clock time of %s: %s", from, ct)
+	}
+}
@@ -0,0 +1,152 @@
+package vocdriver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CredentialsProvider supplies the Volvo On Call username and password
+//
+// Credentials is called before every request so rotated passwords take effect without rebuilding the Client
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (username, password string, err error)
+}
+
+// CredentialsFunc is an adapter to allow the use of ordinary functions as a CredentialsProvider
+type CredentialsFunc func(ctx context.Context) (username, password string, err error)
+
+func (f CredentialsFunc) Credentials(ctx context.Context) (username, password string, err error) {
+	return f(ctx)
+}
+
+// StaticCredentials provides a fixed username and password
+type StaticCredentials struct {
+	Username string
+	Password string
+}
+
+func (s StaticCredentials) Credentials(ctx context.Context) (username, password string, err error) {
+	return s.Username, s.Password, nil
+}
+
+// Default environment variables read by EnvCredentials
+const (
+	EnvUsername = "VOC_USERNAME"
+	EnvPassword = "VOC_PASSWORD"
+)
+
+// EnvCredentials reads the username and password from environment variables (VOC_USERNAME and VOC_PASSWORD by default)
+type EnvCredentials struct {
+	UsernameVar string // defaults to EnvUsername
+	PasswordVar string // defaults to EnvPassword
+}
+
+func (e EnvCredentials) Credentials(ctx context.Context) (username, password string, err error) {
+	usernameVar, passwordVar := e.UsernameVar, e.PasswordVar
+	if usernameVar == "" {
+		usernameVar = EnvUsername
+	}
+	if passwordVar == "" {
+		passwordVar = EnvPassword
+	}
+	username, password = os.Getenv(usernameVar), os.Getenv(passwordVar)
+	if username == "" || password == "" {
+		return "", "", fmt.Errorf("environment variables %s and %s must be set", usernameVar, passwordVar)
+	}
+	return username, password, nil
+}
+
+// FileCredentials reads the username and password from a file in the format of the voc configuration file, e.g.:
+//
+//	username: my-volvo-username
+//	password: my-secret-password
+//
+// Lines starting with # and unknown keys are ignored. The file is re-read on every call
+type FileCredentials struct {
+	Path string
+}
+
+func (f FileCredentials) Credentials(ctx context.Context) (username, password string, err error) {
+	file, err := os.Open(f.Path)
+	if err != nil {
+		return "", "", err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "username":
+			username = strings.TrimSpace(value)
+		case "password":
+			password = strings.TrimSpace(value)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return "", "", err
+	}
+	if username == "" || password == "" {
+		return "", "", fmt.Errorf("%s must contain both username and password", f.Path)
+	}
+	return username, password, nil
+}
+
+// CommandCredentials runs an external command (e.g.: pass show volvo) and uses the first line of its output as password
+//
+// The output is cached for CacheTTL. A zero CacheTTL runs the command before every request
+type CommandCredentials struct {
+	Username string
+	Name     string   // the command to run, e.g.: pass
+	Args     []string // the arguments of the command, e.g.: show, volvo
+	CacheTTL time.Duration
+
+	mu       sync.Mutex
+	password string
+	expires  time.Time
+}
+
+// NewCommandCredentials returns a CommandCredentials running command split on white space
+func NewCommandCredentials(username, command string, cacheTTL time.Duration) (*CommandCredentials, error) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("password command must not be empty")
+	}
+	return &CommandCredentials{Username: username, Name: fields[0], Args: fields[1:], CacheTTL: cacheTTL}, nil
+}
+
+func (c *CommandCredentials) Credentials(ctx context.Context) (username, password string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.password != "" && time.Now().Before(c.expires) {
+		return c.Username, c.password, nil
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.Name, c.Args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", "", fmt.Errorf("password command %s failed: %w: %s", c.Name, err, strings.TrimSpace(stderr.String()))
+	}
+	password, _, _ = strings.Cut(string(out), "\n")
+	password = strings.TrimRight(password, "\r")
+	if password == "" {
+		return "", "", fmt.Errorf("password command %s returned an empty password", c.Name)
+	}
+	c.password, c.expires = password, time.Now().Add(c.CacheTTL)
+	return c.Username, password, nil
+}
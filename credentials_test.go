@@ -0,0 +1,69 @@
+package vocdriver_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+	"github.com/theriverman/VolvoOnCall/voctest"
+)
+
+func TestFileCredentials_Rotation(t *testing.T) {
+	srv := voctest.NewServer(nil)
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), ".voc.conf")
+	write := func(password string) {
+		content := "# voc configuration\nusername: " + voctest.Username + "\npassword: " + password + "\ndefaultCarVin: " + voctest.VIN + "\n"
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("outdated-password")
+
+	client, err := srv.NewClient(vocdriver.WithCredentialsProvider(vocdriver.FileCredentials{Path: path}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if _, err = client.CustomerAccount.GetAccount(ctx); !vocdriver.IsUnauthorized(err) {
+		t.Fatalf("expected an unauthorized error, got %v", err)
+	}
+	write(voctest.Password)
+	if _, err = client.CustomerAccount.GetAccount(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEnvCredentials(t *testing.T) {
+	t.Setenv(vocdriver.EnvUsername, voctest.Username)
+	t.Setenv(vocdriver.EnvPassword, "")
+	if _, _, err := (vocdriver.EnvCredentials{}).Credentials(context.Background()); err == nil {
+		t.Error("expected an error for a missing password")
+	}
+	t.Setenv(vocdriver.EnvPassword, voctest.Password)
+	username, password, err := vocdriver.EnvCredentials{}.Credentials(context.Background())
+	if err != nil || username != voctest.Username || password != voctest.Password {
+		t.Errorf("unexpected credentials: %q, %q, %v", username, password, err)
+	}
+}
+
+func TestCommandCredentials(t *testing.T) {
+	if _, err := exec.LookPath("echo"); err != nil {
+		t.Skip("echo is not available")
+	}
+	provider, err := vocdriver.NewCommandCredentials(voctest.Username, "echo "+voctest.Password, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	username, password, err := provider.Credentials(context.Background())
+	if err != nil || username != voctest.Username || password != voctest.Password {
+		t.Errorf("unexpected credentials: %q, %q, %v", username, password, err)
+	}
+	if _, err = vocdriver.NewCommandCredentials(voctest.Username, " ", 0); err == nil {
+		t.Error("expected an error for an empty command")
+	}
+}
@@ -0,0 +1,112 @@
+package vocdriver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrVehicleUnsupported is wrapped by every error returned when an operation is refused because
+// the vehicle's attributes state that the requested feature is not available
+var ErrVehicleUnsupported = errors.New("operation is not supported by the vehicle")
+
+// ErrLimitExceeded is wrapped by every error returned when a change is refused because it would exceed
+// a limit stated by the vehicle's attributes (e.g.: the maximum number of climatization timers)
+var ErrLimitExceeded = errors.New("limit of the vehicle exceeded")
+
+// APIError is returned by RequestService whenever the VOC API responds with a non-2xx status code
+//
+// Use errors.As to access it or one of the helpers below (IsUnauthorized, IsNotFound, etc.)
+type APIError struct {
+	StatusCode  int         // HTTP status code of the response
+	Method      string      // HTTP method of the request
+	URL         string      // absolute URL of the request
+	Header      http.Header // headers of the response
+	Code        string      // VOC error code (errorLabel), if any was returned
+	Description string      // VOC error description (errorDescription), if any was returned
+	Body        []byte      // raw response body
+}
+
+// apiErrorBody represents the JSON structure of an error returned by the VOC API
+type apiErrorBody struct {
+	ErrorLabel       string `json:"errorLabel"`
+	ErrorDescription string `json:"errorDescription"`
+}
+
+func newAPIError(response *http.Response, rawResponseBody []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: response.StatusCode,
+		Header:     response.Header,
+		Body:       rawResponseBody,
+	}
+	if response.Request != nil {
+		apiErr.Method = response.Request.Method
+		apiErr.URL = response.Request.URL.String()
+	}
+	var body apiErrorBody
+	if err := json.Unmarshal(rawResponseBody, &body); err == nil {
+		apiErr.Code = body.ErrorLabel
+		apiErr.Description = body.ErrorDescription
+	}
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	s := fmt.Sprintf("%s %s: %d %s", e.Method, e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+	switch {
+	case e.Code != "" && e.Description != "":
+		return fmt.Sprintf("%s: %s (%s)", s, e.Description, e.Code)
+	case e.Code != "":
+		return fmt.Sprintf("%s: %s", s, e.Code)
+	case len(e.Body) > 0:
+		return fmt.Sprintf("%s: %s", s, strings.TrimSpace(string(e.Body)))
+	default:
+		return s
+	}
+}
+
+// Is reports ErrVehicleUnsupported as a match when the VOC API refused the request due to a missing vehicle capability
+func (e *APIError) Is(target error) bool {
+	return target == ErrVehicleUnsupported && e.isVehicleUnsupported()
+}
+
+func (e *APIError) isVehicleUnsupported() bool {
+	if e.StatusCode == http.StatusNotImplemented {
+		return true
+	}
+	code := strings.ToLower(e.Code)
+	return strings.Contains(code, "notsupported") || strings.Contains(code, "unsupported")
+}
+
+func hasStatusCode(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+// IsUnauthorized returns true if err is an *APIError caused by invalid or missing credentials (401)
+func IsUnauthorized(err error) bool {
+	return hasStatusCode(err, http.StatusUnauthorized)
+}
+
+// IsNotFound returns true if err is an *APIError caused by a resource which does not exist (404)
+func IsNotFound(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+// IsRateLimited returns true if err is an *APIError caused by too many requests (429)
+func IsRateLimited(err error) bool {
+	return hasStatusCode(err, http.StatusTooManyRequests)
+}
+
+// IsVehicleUnsupported returns true if err was caused by a feature the vehicle does not support,
+// either detected locally from the vehicle's attributes or reported by the VOC API
+func IsVehicleUnsupported(err error) bool {
+	return errors.Is(err, ErrVehicleUnsupported)
+}
+
+// IsLimitExceeded returns true if err was caused by a change exceeding a limit stated by the vehicle's attributes
+func IsLimitExceeded(err error) bool {
+	return errors.Is(err, ErrLimitExceeded)
+}
@@ -0,0 +1,59 @@
+package vocdriver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/customeraccounts":
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"errorLabel":"InvalidCredentials","errorDescription":"Invalid username or password"}`)
+		case "/vehicles/YV1ABCDEFGH123456/honkAndBlink":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"errorLabel":"FunctionNotSupported","errorDescription":"Honk and blink is not supported"}`)
+		default:
+			w.Header().Set("Retry-After", "10")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, "slow down")
+		}
+	}))
+	defer srv.Close()
+
+	client := &Client{BaseURL: srv.URL}
+	if err := client.Initialise(); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	_, err := client.CustomerAccount.GetAccount(ctx)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusUnauthorized || apiErr.Method != http.MethodGet || apiErr.URL != srv.URL+"/customeraccounts" {
+		t.Errorf("unexpected error details: %+v", apiErr)
+	}
+	if apiErr.Code != "InvalidCredentials" || apiErr.Description != "Invalid username or password" {
+		t.Errorf("unexpected VOC error: %q / %q", apiErr.Code, apiErr.Description)
+	}
+	if !IsUnauthorized(err) || IsNotFound(err) || IsRateLimited(err) || IsVehicleUnsupported(err) {
+		t.Errorf("unexpected helper results for %v", err)
+	}
+
+	_, err = client.Vehicles.HonkAndBlink(ctx, "YV1ABCDEFGH123456", &Position{})
+	if !IsVehicleUnsupported(err) {
+		t.Errorf("expected IsVehicleUnsupported for %v", err)
+	}
+
+	_, err = client.Vehicles.GetVehicleTripsByVIN(ctx, "YV1ABCDEFGH123456")
+	if !IsRateLimited(err) || !errors.As(err, &apiErr) || apiErr.Header.Get("Retry-After") != "10" || string(apiErr.Body) != "slow down" {
+		t.Errorf("expected rate limited error carrying headers and body, got %v", err)
+	}
+}
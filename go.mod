@@ -1,5 +1,3 @@
 module github.com/theriverman/VolvoOnCall
 
 go 1.19
-
-require github.com/joho/godotenv v1.4.0
@@ -0,0 +1,151 @@
+package vocdriver
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// HeaterTimerCount is the number of timers of the remote heater. Timers are numbered from 1
+const HeaterTimerCount = 2
+
+// Heater is the state of the remote heater reported in VehicleStatus
+type Heater struct {
+	SeatSelection SeatSelection `json:"seatSelection"`
+	Status        string        `json:"status"` // on or off
+	Timer1        HeaterTimer   `json:"timer1"`
+	Timer2        HeaterTimer   `json:"timer2"`
+	Timestamp     Timestamp     `json:"timestamp"`
+}
+
+// HeaterTimer starts the heater every day at Time while State is true
+type HeaterTimer struct {
+	Time  ClockTime `json:"time"` // example value: 07:30
+	State bool      `json:"state"`
+}
+
+// SeatSelection are the seats heated along with the cabin
+type SeatSelection struct {
+	FrontDriverSide    bool `json:"frontDriverSide"`
+	FrontPassengerSide bool `json:"frontPassengerSide"`
+	RearDriverSide     bool `json:"rearDriverSide"`
+	RearPassengerSide  bool `json:"rearPassengerSide"`
+	RearMid            bool `json:"rearMid"`
+}
+
+// Timers returns the timers of the heater ordered by their number
+func (h Heater) Timers() []HeaterTimer {
+	return []HeaterTimer{h.Timer1, h.Timer2}
+}
+
+// validate returns an error if Time is unset
+func (ht HeaterTimer) validate() error {
+	if ht.Time.IsZero() {
+		return fmt.Errorf("invalid heater timer: time must be set")
+	}
+	return nil
+}
+
+// GetHeater returns the heater state from the status of the car
+func (v *VehiclesService) GetHeater(ctx context.Context, vin string) (heater *Heater, err error) {
+	status, err := v.GetVehicleStatusByVIN(ctx, vin)
+	if err != nil {
+		return nil, err
+	}
+	return &status.Heater, nil
+}
+
+// UpdateHeaterTimer replaces the timer numbered timer (1 - HeaterTimerCount) of the heater
+func (v *VehiclesService) UpdateHeaterTimer(ctx context.Context, vin string, timer int, heaterTimer *HeaterTimer) (heaterTimerResponse *HeaterTimer, err error) {
+	if vin == "" {
+		return nil, fmt.Errorf("vin must not be empty")
+	}
+	if timer < 1 || timer > HeaterTimerCount {
+		return nil, fmt.Errorf("heater timer must be between 1 and %d, got %d", HeaterTimerCount, timer)
+	}
+	if heaterTimer == nil {
+		return nil, fmt.Errorf("heater timer must not be empty")
+	}
+	if err = heaterTimer.validate(); err != nil {
+		return nil, err
+	}
+	url := v.client.MakeURL(v.Endpoint, vin, "heater", "timers", strconv.Itoa(timer))
+	if _, err = v.client.Request.Put(ctx, url, heaterTimer, &heaterTimerResponse); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// UpdateSeatSelection replaces the seats heated along with the cabin
+func (v *VehiclesService) UpdateSeatSelection(ctx context.Context, vin string, seatSelection *SeatSelection) (seatSelectionResponse *SeatSelection, err error) {
+	if vin == "" {
+		return nil, fmt.Errorf("vin must not be empty")
+	}
+	if seatSelection == nil {
+		return nil, fmt.Errorf("seat selection must not be empty")
+	}
+	url := v.client.MakeURL(v.Endpoint, vin, "heater", "seatSelection")
+	if _, err = v.client.Request.Put(ctx, url, seatSelection, &seatSelectionResponse); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// GetHeaterTimers returns the current timers of the heater ordered by their number
+func (v Vehicle) GetHeaterTimers(ctx context.Context) (timers []HeaterTimer, err error) {
+	if err = v.checkRemoteHeaterSupported(); err != nil {
+		return nil, err
+	}
+	heater, err := v.client.Vehicles.GetHeater(ctx, v.VehicleID)
+	if err != nil {
+		return nil, err
+	}
+	return heater.Timers(), nil
+}
+
+// SetHeaterTimer replaces the timer numbered timer (1 - HeaterTimerCount) of the heater
+func (v Vehicle) SetHeaterTimer(ctx context.Context, timer int, heaterTimer *HeaterTimer) (*HeaterTimer, error) {
+	if err := v.checkRemoteHeaterSupported(); err != nil {
+		return nil, err
+	}
+	return v.client.Vehicles.UpdateHeaterTimer(ctx, v.VehicleID, timer, heaterTimer)
+}
+
+// EnableHeaterTimer turns on the timer numbered timer, keeping its time
+func (v Vehicle) EnableHeaterTimer(ctx context.Context, timer int) (*HeaterTimer, error) {
+	return v.setHeaterTimerState(ctx, timer, true)
+}
+
+// DisableHeaterTimer turns off the timer numbered timer, keeping its time
+func (v Vehicle) DisableHeaterTimer(ctx context.Context, timer int) (*HeaterTimer, error) {
+	return v.setHeaterTimerState(ctx, timer, false)
+}
+
+func (v Vehicle) setHeaterTimerState(ctx context.Context, timer int, state bool) (*HeaterTimer, error) {
+	timers, err := v.GetHeaterTimers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if timer < 1 || timer > len(timers) {
+		return nil, fmt.Errorf("heater timer must be between 1 and %d, got %d", len(timers), timer)
+	}
+	ht := timers[timer-1]
+	ht.State = state
+	return v.client.Vehicles.UpdateHeaterTimer(ctx, v.VehicleID, timer, &ht)
+}
+
+// SetSeatSelection replaces the seats heated along with the cabin
+func (v Vehicle) SetSeatSelection(ctx context.Context, seatSelection *SeatSelection) (*SeatSelection, error) {
+	if err := v.checkRemoteHeaterSupported(); err != nil {
+		return nil, err
+	}
+	return v.client.Vehicles.UpdateSeatSelection(ctx, v.VehicleID, seatSelection)
+}
+
+// checkRemoteHeaterSupported returns an error wrapping ErrVehicleUnsupported if the car has no remote heater
+func (v Vehicle) checkRemoteHeaterSupported() error {
+	if !v.IsRemoteHeaterSupported() {
+		return fmt.Errorf("remote heater is not supported by %s [%s]: %w", v.Attributes.RegistrationNumber, v.Attributes.Vin, ErrVehicleUnsupported)
+	}
+	return nil
+}
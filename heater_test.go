@@ -0,0 +1,80 @@
+package vocdriver_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+	"github.com/theriverman/VolvoOnCall/voctest"
+)
+
+func TestVehicle_HeaterTimers(t *testing.T) {
+	srv, _, vehicle := newTestVehicle(t, nil)
+	ctx := context.Background()
+
+	if _, err := vehicle.SetHeaterTimer(ctx, 2, &vocdriver.HeaterTimer{Time: vocdriver.NewClockTime(6, 15), State: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vehicle.EnableHeaterTimer(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	timers, err := vehicle.GetHeaterTimers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(timers) != 2 || timers[0] != (vocdriver.HeaterTimer{Time: vocdriver.NewClockTime(7, 30), State: true}) || timers[1] != (vocdriver.HeaterTimer{Time: vocdriver.NewClockTime(6, 15), State: true}) {
+		t.Errorf("unexpected timers: %+v", timers)
+	}
+
+	if _, err = vehicle.DisableHeaterTimer(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := srv.Handler.Vehicle(voctest.VIN); v.Status.Heater.Timer2 != (vocdriver.HeaterTimer{Time: vocdriver.NewClockTime(6, 15)}) {
+		t.Errorf("unexpected timer 2: %+v", v.Status.Heater.Timer2)
+	}
+
+	for _, timer := range []int{0, 3} {
+		if _, err = vehicle.SetHeaterTimer(ctx, timer, &vocdriver.HeaterTimer{Time: vocdriver.NewClockTime(6, 15)}); err == nil {
+			t.Errorf("expected timer %d to be rejected", timer)
+		}
+	}
+	if _, err = vehicle.SetHeaterTimer(ctx, 1, &vocdriver.HeaterTimer{State: true}); err == nil {
+		t.Error("expected a timer without time to be rejected")
+	}
+	if _, err = vehicle.SetHeaterTimer(ctx, 1, nil); err == nil {
+		t.Error("expected a nil timer to be rejected")
+	}
+}
+
+func TestVehicle_SetSeatSelection(t *testing.T) {
+	_, client, vehicle := newTestVehicle(t, nil)
+	ctx := context.Background()
+	seats := vocdriver.SeatSelection{FrontDriverSide: true, RearMid: true}
+	if _, err := vehicle.SetSeatSelection(ctx, &seats); err != nil {
+		t.Fatal(err)
+	}
+	heater, err := client.Vehicles.GetHeater(ctx, voctest.VIN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if heater.SeatSelection != seats {
+		t.Errorf("unexpected seat selection: %+v", heater.SeatSelection)
+	}
+	if _, err = vehicle.SetSeatSelection(ctx, nil); err == nil {
+		t.Error("expected a nil seat selection to be rejected")
+	}
+}
+
+func TestVehicle_HeaterTimersUnsupported(t *testing.T) {
+	fixtures := voctest.DefaultFixtures()
+	fixtures.Vehicles[0].Attributes.RemoteHeaterSupported = false
+	_, _, vehicle := newTestVehicle(t, fixtures)
+	ctx := context.Background()
+	if _, err := vehicle.EnableHeaterTimer(ctx, 1); !errors.Is(err, vocdriver.ErrVehicleUnsupported) {
+		t.Errorf("expected ErrVehicleUnsupported, got %v", err)
+	}
+	if _, err := vehicle.SetSeatSelection(ctx, &vocdriver.SeatSelection{}); !errors.Is(err, vocdriver.ErrVehicleUnsupported) {
+		t.Errorf("expected ErrVehicleUnsupported, got %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package vocdriver_test
+
+import (
+	"context"
+	"testing"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+	"github.com/theriverman/VolvoOnCall/voctest"
+)
+
+// newTestClient starts a voctest.Server seeded with fixtures (DefaultFixtures() if nil), which is closed when the test
+// ends, and returns it along with a client configured by opts
+func newTestClient(t *testing.T, fixtures *voctest.Fixtures, opts ...vocdriver.Option) (*voctest.Server, *vocdriver.Client) {
+	t.Helper()
+	srv := voctest.NewServer(fixtures)
+	t.Cleanup(srv.Close)
+	client, err := srv.NewClient(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srv, client
+}
+
+// newTestVehicle works like newTestClient and retrieves the vehicle voctest.VIN too
+func newTestVehicle(t *testing.T, fixtures *voctest.Fixtures, opts ...vocdriver.Option) (*voctest.Server, *vocdriver.Client, *vocdriver.Vehicle) {
+	t.Helper()
+	srv, client := newTestClient(t, fixtures, opts...)
+	vehicle, err := client.Vehicles.GetVehicleByVIN(context.Background(), voctest.VIN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srv, client, vehicle
+}
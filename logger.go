@@ -0,0 +1,121 @@
+package vocdriver
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Level is the severity of a log event
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	default:
+		return "LEVEL(" + strconv.Itoa(int(l)) + ")"
+	}
+}
+
+// Logger receives the structured events emitted by the Client (requests, responses, latencies, service status polls)
+//
+// keyvals are alternating key/value pairs, e.g.: "method", "GET", "status", 200. Secrets are never passed to a Logger
+type Logger interface {
+	Log(level Level, msg string, keyvals ...interface{})
+}
+
+// LoggerFunc adapts an ordinary function to the Logger interface
+type LoggerFunc func(level Level, msg string, keyvals ...interface{})
+
+func (f LoggerFunc) Log(level Level, msg string, keyvals ...interface{}) {
+	f(level, msg, keyvals...)
+}
+
+// NewStdLogger returns a Logger writing events at or above minLevel to w as `LEVEL message key=value ...` lines
+func NewStdLogger(w io.Writer, minLevel Level) Logger {
+	return &stdLogger{
+		logger:   log.New(w, "", log.LstdFlags),
+		minLevel: minLevel,
+	}
+}
+
+type stdLogger struct {
+	logger   *log.Logger
+	minLevel Level
+}
+
+func (l *stdLogger) Log(level Level, msg string, keyvals ...interface{}) {
+	if level < l.minLevel {
+		return
+	}
+	var sb strings.Builder
+	sb.WriteString(level.String())
+	sb.WriteString(" ")
+	sb.WriteString(msg)
+	for i := 0; i < len(keyvals); i += 2 {
+		var value interface{} = "(MISSING)"
+		if i+1 < len(keyvals) {
+			value = keyvals[i+1]
+		}
+		fmt.Fprintf(&sb, " %v=%s", keyvals[i], formatLogValue(value))
+	}
+	l.logger.Print(sb.String())
+}
+
+func formatLogValue(value interface{}) string {
+	s := fmt.Sprint(value)
+	if s == "" || strings.ContainsAny(s, " =\"\t\n") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Log(Level, string, ...interface{}) {}
+
+// logger returns Client.Logger if set, a debug logger writing to stderr in Verbose mode, otherwise a no-op Logger
+func (c *Client) logger() Logger {
+	switch {
+	case c == nil:
+		return nopLogger{}
+	case c.Logger != nil:
+		return c.Logger
+	case c.Verbose:
+		c.verboseOnce.Do(func() {
+			c.verboseLogger = NewStdLogger(os.Stderr, LevelDebug)
+		})
+		return c.verboseLogger
+	default:
+		return nopLogger{}
+	}
+}
+
+// redactSecretHeaders returns a copy of header with the values of secretHeaders replaced by redactedValue
+func redactSecretHeaders(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, key := range secretHeaders {
+		if redacted.Get(key) != "" {
+			redacted.Set(key, redactedValue)
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,59 @@
+package vocdriver_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+	"github.com/theriverman/VolvoOnCall/voctest"
+)
+
+func TestClient_Logger(t *testing.T) {
+	_, client := newTestClient(t, nil)
+
+	var events []string
+	client.Logger = vocdriver.LoggerFunc(func(level vocdriver.Level, msg string, keyvals ...interface{}) {
+		events = append(events, fmt.Sprintln(level, msg, keyvals))
+	})
+	if _, err := client.Vehicles.GetVehicleStatusByVIN(context.Background(), voctest.VIN); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || !strings.HasPrefix(events[0], "DEBUG sending request ") || !strings.Contains(events[1], "status 200 latency ") {
+		t.Errorf("unexpected events: %q", events)
+	}
+	if strings.Contains(strings.Join(events, "\n"), "Basic ") {
+		t.Error("expected the Authorization header to be redacted")
+	}
+}
+
+func TestClient_VerboseConcurrent(t *testing.T) {
+	_, client := newTestClient(t, nil)
+	client.Verbose = true
+
+	// the verbose logger is created by the first request, run with -race to detect unsynchronized access
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Vehicles.GetVehicleStatusByVIN(context.Background(), voctest.VIN); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestNewStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := vocdriver.NewStdLogger(&buf, vocdriver.LevelInfo)
+	logger.Log(vocdriver.LevelDebug, "dropped")
+	logger.Log(vocdriver.LevelWarn, "unexpected heater status", "vin", voctest.VIN, "status", "on and off")
+	if s := buf.String(); strings.Contains(s, "dropped") || !strings.HasSuffix(s, `WARN unexpected heater status vin=YV1ABCDEFGH123456 status="on and off"`+"\n") {
+		t.Errorf("unexpected output: %q", s)
+	}
+}
@@ -0,0 +1,164 @@
+package vocdriver
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ServiceProgress is a status transition of an Operation
+type ServiceProgress struct {
+	Status          ServiceStatus
+	StatusTimestamp Timestamp // when the transition happened according to the VOC API
+	ObservedAt      time.Time // when the transition was polled
+	FailureReason   string
+}
+
+// Operation is a handle to a remote command (e.g.: a lock) tracked in the background until it finishes
+//
+// Unlike EvaluateServiceStatus, an Operation doesn't block the caller:
+//
+//	op, err := vehicle.Lock(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	for p := range op.Progress() {
+//		fmt.Printf("%s: %s\n", p.ObservedAt.Format(time.Kitchen), p.Status)
+//	}
+//	return op.Err()
+//
+// Polling starts on the first call to Wait, Done or Progress, so an Operation nobody asks about sends no requests
+type Operation struct {
+	mu          sync.Mutex
+	status      VehicleServiceStatus
+	err         error
+	transitions []ServiceProgress // every transition observed so far, replayed by Progress
+	finished    bool
+	changed     *sync.Cond // broadcast when transitions or finished change
+	done        chan struct{}
+	cancel      context.CancelFunc
+
+	poll         func()
+	pollOnce     sync.Once
+	progress     chan ServiceProgress
+	progressOnce sync.Once
+}
+
+// Track returns the Operation handle of the operation behind vss, which is polled in the background following policy
+//
+// If policy is nil, the PollPolicy of the Client is used as in EvaluateServiceStatusAuto.
+// Tracking stops once the operation finishes, times out, ctx is cancelled or Operation.Cancel is called
+func (v *VehiclesService) Track(ctx context.Context, vss *VehicleServiceStatus, policy *PollPolicy) *Operation {
+	ctx, cancel := context.WithCancel(ctx)
+	op := &Operation{
+		status: *vss,
+		done:   make(chan struct{}),
+		cancel: cancel,
+	}
+	op.changed = sync.NewCond(&op.mu)
+	tracked := *vss // polled privately so the caller's vss is never modified concurrently
+	op.poll = func() {
+		defer cancel()
+		var err error
+		if policy == nil {
+			policy, err = v.pollPolicy(ctx, &tracked)
+		}
+		if err == nil {
+			var last ServiceStatus
+			err = v.pollServiceStatus(ctx, &tracked, policy, func(vss *VehicleServiceStatus) {
+				op.mu.Lock()
+				defer op.mu.Unlock()
+				op.status = *vss
+				if vss.Status != last {
+					last = vss.Status
+					op.transitions = append(op.transitions, ServiceProgress{Status: vss.Status, StatusTimestamp: vss.StatusTimestamp, ObservedAt: time.Now(), FailureReason: vss.FailureReason})
+					op.changed.Broadcast()
+				}
+			})
+		}
+		op.mu.Lock()
+		op.err = err
+		op.finished = true
+		op.changed.Broadcast()
+		op.mu.Unlock()
+		close(op.done)
+	}
+	return op
+}
+
+// start begins polling in the background unless it has already begun
+func (o *Operation) start() {
+	o.pollOnce.Do(func() { go o.poll() })
+}
+
+// Wait blocks until the operation finishes or ctx is cancelled and returns the outcome of the operation
+//
+// Cancelling ctx only stops waiting. Use Cancel to stop tracking the operation
+func (o *Operation) Wait(ctx context.Context) error {
+	o.start()
+	select {
+	case <-o.done:
+		return o.Err()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Done returns a channel closed once the operation finished or tracking stopped
+func (o *Operation) Done() <-chan struct{} {
+	o.start()
+	return o.done
+}
+
+// Progress returns a channel receiving each status transition of the operation. It is closed when Done is
+//
+// No transition is dropped: every call returns the same channel, which receives the transitions observed before it was
+// read too. The channel must be drained, otherwise the goroutine feeding it is never released
+func (o *Operation) Progress() <-chan ServiceProgress {
+	o.start()
+	o.progressOnce.Do(func() {
+		o.progress = make(chan ServiceProgress)
+		go o.forwardProgress()
+	})
+	return o.progress
+}
+
+// forwardProgress sends the transitions to the Progress channel in order and closes it once the operation finished
+func (o *Operation) forwardProgress() {
+	defer close(o.progress)
+	for sent := 0; ; sent++ {
+		o.mu.Lock()
+		for sent == len(o.transitions) && !o.finished {
+			o.changed.Wait()
+		}
+		if sent == len(o.transitions) {
+			o.mu.Unlock()
+			return
+		}
+		p := o.transitions[sent]
+		o.mu.Unlock()
+		o.progress <- p
+	}
+}
+
+// Cancel stops tracking the operation. The command already sent to the car is not recalled
+//
+// If polling has not started yet, it stops right away once started, e.g.: Wait returns context.Canceled
+func (o *Operation) Cancel() {
+	o.cancel()
+}
+
+// Err returns nil while the operation is in progress or if it succeeded,
+// otherwise the reason it failed or tracking stopped (e.g.: context.Canceled after Cancel)
+func (o *Operation) Err() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.err
+}
+
+// Status returns the last polled status of the operation
+func (o *Operation) Status() VehicleServiceStatus {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.status
+}
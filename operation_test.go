@@ -0,0 +1,119 @@
+package vocdriver_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+	"github.com/theriverman/VolvoOnCall/voctest"
+)
+
+func TestOperation_Progress(t *testing.T) {
+	srv, _, vehicle := newTestVehicle(t, nil)
+	srv.Handler.SetScenario(voctest.Scenario{
+		DeliveryDelay:   voctest.Duration(500 * time.Millisecond),
+		CompletionDelay: voctest.Duration(1000 * time.Millisecond),
+	})
+	ctx := context.Background()
+	if err := vehicle.RetrieveHyperlinks(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	op, err := vehicle.UnlockVehicle(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var observed []vocdriver.ServiceStatus
+	for p := range op.Progress() {
+		if p.ObservedAt.IsZero() || p.StatusTimestamp.IsZero() {
+			t.Errorf("missing timestamps in %+v", p)
+		}
+		observed = append(observed, p.Status)
+	}
+	select {
+	case <-op.Done():
+	default:
+		t.Error("expected Done to be closed along with Progress")
+	}
+	if err = op.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(observed) != 3 || observed[0] != vocdriver.ServiceStatusStarted || observed[1] != vocdriver.ServiceStatusMessageDelivered || observed[2] != vocdriver.ServiceStatusSuccessful {
+		t.Errorf("unexpected transitions: %v", observed)
+	}
+	if st := op.Status(); st.ServiceType != vocdriver.ServiceTypeUnlock || !st.Status.IsSuccess() {
+		t.Errorf("unexpected final status: %+v", st)
+	}
+}
+
+func TestOperation_LazyPollingAndLateProgress(t *testing.T) {
+	srv, client := newTestClient(t, nil)
+	srv.Handler.SetScenario(voctest.Scenario{
+		DeliveryDelay:   voctest.Duration(300 * time.Millisecond),
+		CompletionDelay: voctest.Duration(100 * time.Millisecond),
+	})
+	ctx := context.Background()
+	vss, err := client.Vehicles.LockVehicle(ctx, voctest.VIN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	polled := func() (polls int) {
+		for _, r := range srv.Handler.Requests() {
+			if strings.Contains(r.Path, "/services/") {
+				polls++
+			}
+		}
+		return polls
+	}
+	op := client.Vehicles.Track(ctx, vss, &vocdriver.PollPolicy{InitialInterval: 20 * time.Millisecond, Timeout: 5 * time.Second})
+	time.Sleep(100 * time.Millisecond)
+	if n := polled(); n != 0 {
+		t.Fatalf("expected no polls before Wait, got %d", n)
+	}
+
+	if err = op.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if polled() == 0 {
+		t.Error("expected Wait to poll the operation")
+	}
+	// every transition is replayed to a Progress channel read after the operation finished
+	var observed []vocdriver.ServiceStatus
+	for p := range op.Progress() {
+		observed = append(observed, p.Status)
+	}
+	if len(observed) != 3 || observed[0] != vocdriver.ServiceStatusStarted || observed[2] != vocdriver.ServiceStatusSuccessful {
+		t.Errorf("unexpected transitions: %v", observed)
+	}
+}
+
+func TestOperation_Cancel(t *testing.T) {
+	srv, client := newTestClient(t, nil)
+	srv.Handler.SetScenario(voctest.Scenario{Offline: true})
+	ctx := context.Background()
+	vss, err := client.Vehicles.LockVehicle(ctx, voctest.VIN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := client.Vehicles.Track(ctx, vss, nil)
+
+	waitCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if err = op.Wait(waitCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected Wait to give up, got %v", err)
+	}
+	if op.Err() != nil {
+		t.Errorf("the operation should still be in progress, got %v", op.Err())
+	}
+
+	op.Cancel()
+	if err = op.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a cancelled operation, got %v", err)
+	}
+	if vss.Status != vocdriver.ServiceStatusStarted {
+		t.Errorf("the tracked status must not be modified, got %s", vss.Status)
+	}
+}
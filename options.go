@@ -0,0 +1,197 @@
+package vocdriver
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Option configures a Client created by NewClient
+type Option func(o *clientOptions) error
+
+// clientOptions collects the values of every Option before they are validated as a whole
+type clientOptions struct {
+	baseURL          string
+	region           Region
+	regionSet        bool // RegionEurope is the empty string, so the region alone cannot tell whether WithRegion was used
+	httpClient       *http.Client
+	timeout          time.Duration
+	credentials      CredentialsProvider
+	headers          map[string]string
+	userAgentProfile UserAgentProfile
+	retryPolicy      *RetryPolicy
+	pollPolicy       *PollPolicy
+	logger           Logger
+}
+
+// UserAgentProfile describes the app the requests sent to the VOC API appear to come from
+type UserAgentProfile struct {
+	AppName        string // X-App-Name
+	ClientVersion  string // X-Client-Version
+	DeviceID       string // X-Device-Id
+	OriginatorType string // X-Originator-Type
+	OSType         string // X-OS-Type
+	OSVersion      string // X-OS-Version
+}
+
+// DefaultUserAgentProfile mimics the official Volvo On Call Android app
+var DefaultUserAgentProfile = UserAgentProfile{
+	AppName:        "Volvo On Call",
+	ClientVersion:  "4.4.5.21126",
+	DeviceID:       "Device",
+	OriginatorType: "App",
+	OSType:         "Android",
+	OSVersion:      "22",
+}
+
+// WithRegion selects the service region. RegionAuto detects it using the credentials. Cannot be combined with WithBaseURL
+//
+// Use ParseRegion to convert user input (e.g.: na, cn, North America)
+func WithRegion(region Region) Option {
+	return func(o *clientOptions) error {
+		o.region = region
+		o.regionSet = true
+		return nil
+	}
+}
+
+// WithBaseURL sets a custom absolute API URL (e.g.: a proxy or a voctest server). Cannot be combined with WithRegion
+func WithBaseURL(baseURL string) Option {
+	return func(o *clientOptions) error {
+		if err := validateBaseURL(baseURL); err != nil {
+			return err
+		}
+		o.baseURL = baseURL
+		return nil
+	}
+}
+
+// WithHTTPClient sets the http.Client used to send requests. Cannot be combined with WithTimeout
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *clientOptions) error {
+		if httpClient == nil {
+			return fmt.Errorf("http client must not be nil")
+		}
+		o.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithTimeout sets the time limit of each request sent by the default http.Client. Cannot be combined with WithHTTPClient
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) error {
+		if timeout <= 0 {
+			return fmt.Errorf("timeout must be positive, got %s", timeout)
+		}
+		o.timeout = timeout
+		return nil
+	}
+}
+
+// WithCredentials sets the static Volvo On Call username and password used to authenticate every request
+func WithCredentials(username, password string) Option {
+	return func(o *clientOptions) error {
+		if username == "" || password == "" {
+			return fmt.Errorf("username and password must not be empty")
+		}
+		o.credentials = StaticCredentials{Username: username, Password: password}
+		return nil
+	}
+}
+
+// WithCredentialsProvider sets the CredentialsProvider resolved before every request (e.g.: EnvCredentials, CommandCredentials)
+func WithCredentialsProvider(provider CredentialsProvider) Option {
+	return func(o *clientOptions) error {
+		if provider == nil {
+			return fmt.Errorf("credentials provider must not be nil")
+		}
+		o.credentials = provider
+		return nil
+	}
+}
+
+// WithHeaders adds extra headers to every request. Use WithCredentials or WithCredentialsProvider instead of setting Authorization
+func WithHeaders(headers map[string]string) Option {
+	return func(o *clientOptions) error {
+		for k := range headers {
+			if http.CanonicalHeaderKey(k) == "Authorization" {
+				return fmt.Errorf("the Authorization header must be set using WithCredentials or WithCredentialsProvider")
+			}
+		}
+		if o.headers == nil {
+			o.headers = map[string]string{}
+		}
+		for k, v := range headers {
+			o.headers[k] = v
+		}
+		return nil
+	}
+}
+
+// WithUserAgentProfile overrides DefaultUserAgentProfile. Every field of profile must be set
+func WithUserAgentProfile(profile UserAgentProfile) Option {
+	return func(o *clientOptions) error {
+		if profile.AppName == "" || profile.ClientVersion == "" || profile.DeviceID == "" ||
+			profile.OriginatorType == "" || profile.OSType == "" || profile.OSVersion == "" {
+			return fmt.Errorf("every field of the user agent profile must be set: %+v", profile)
+		}
+		o.userAgentProfile = profile
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the RetryPolicy of the Client. See DefaultRetryPolicy
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(o *clientOptions) error {
+		o.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithPollPolicy sets the PollPolicy used to evaluate remote commands. See DefaultPollPolicy
+func WithPollPolicy(policy *PollPolicy) Option {
+	return func(o *clientOptions) error {
+		if policy != nil {
+			if err := policy.validate(); err != nil {
+				return err
+			}
+		}
+		o.pollPolicy = policy
+		return nil
+	}
+}
+
+// WithLogger sets the Logger receiving the structured events of the Client
+func WithLogger(logger Logger) Option {
+	return func(o *clientOptions) error {
+		o.logger = logger
+		return nil
+	}
+}
+
+// validate checks the combination of the options
+func (o *clientOptions) validate() error {
+	if o.baseURL != "" && o.regionSet {
+		return fmt.Errorf("WithBaseURL and WithRegion cannot be combined: the region must be part of the base URL")
+	}
+	if o.region == RegionAuto && o.credentials == nil {
+		return fmt.Errorf("WithRegion(RegionAuto) requires WithCredentials to detect the region")
+	}
+	if o.httpClient != nil && o.timeout > 0 {
+		return fmt.Errorf("WithHTTPClient and WithTimeout cannot be combined: set the Timeout of the provided http.Client instead")
+	}
+	return nil
+}
+
+// validateBaseURL returns an error unless baseURL is an absolute http(s) URL
+func validateBaseURL(baseURL string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return fmt.Errorf("invalid base URL %q: %w", baseURL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid base URL %q: must be an absolute http(s) URL", baseURL)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package vocdriver_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+)
+
+func TestNewClient_Options(t *testing.T) {
+	profile := vocdriver.UserAgentProfile{
+		AppName:        "Volvo On Call",
+		ClientVersion:  "5.0.0",
+		DeviceID:       "Device",
+		OriginatorType: "App",
+		OSType:         "iOS",
+		OSVersion:      "16",
+	}
+	client, err := vocdriver.NewClient(
+		vocdriver.WithBaseURL("http://127.0.0.1:8080/"),
+		vocdriver.WithTimeout(5*time.Second),
+		vocdriver.WithCredentials("john.doe@example.com", "secret"),
+		vocdriver.WithUserAgentProfile(profile),
+		vocdriver.WithHeaders(map[string]string{"X-Request-Source": "tests"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := client.MakeURL("customeraccounts"); got != "http://127.0.0.1:8080/customeraccounts" {
+		t.Errorf("unexpected URL: %s", got)
+	}
+	if client.HTTPClient.Timeout != 5*time.Second {
+		t.Errorf("unexpected timeout: %s", client.HTTPClient.Timeout)
+	}
+	h := client.Headers
+	if h.Get("X-OS-Type") != "iOS" || h.Get("X-Client-Version") != "5.0.0" || h.Get("X-Request-Source") != "tests" {
+		t.Errorf("unexpected headers: %v", h)
+	}
+	if client.Credentials != (vocdriver.StaticCredentials{Username: "john.doe@example.com", Password: "secret"}) {
+		t.Errorf("unexpected credentials: %+v", client.Credentials)
+	}
+}
+
+func TestNewClient_InvalidOptions(t *testing.T) {
+	for name, opts := range map[string][]vocdriver.Option{
+		"region and base URL":      {vocdriver.WithRegion("na"), vocdriver.WithBaseURL("https://example.com")},
+		"europe and base URL":      {vocdriver.WithRegion(vocdriver.RegionEurope), vocdriver.WithBaseURL("https://example.com")},
+		"http client and timeout":  {vocdriver.WithHTTPClient(&http.Client{}), vocdriver.WithTimeout(time.Second)},
+		"relative base URL":        {vocdriver.WithBaseURL("/customerapi")},
+		"nil http client":          {vocdriver.WithHTTPClient(nil)},
+		"negative timeout":         {vocdriver.WithTimeout(-time.Second)},
+		"missing password":         {vocdriver.WithCredentials("john.doe@example.com", "")},
+		"authorization header":     {vocdriver.WithHeaders(map[string]string{"authorization": "Basic Zm9vOmJhcg=="})},
+		"incomplete agent profile": {vocdriver.WithUserAgentProfile(vocdriver.UserAgentProfile{AppName: "Volvo On Call"})},
+	} {
+		if _, err := vocdriver.NewClient(opts...); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package vocdriver
+
+import (
+	"context"
+	"fmt"
+)
+
+// POI is a point of interest (e.g.: a destination) sent to the navigation system of the car
+type POI struct {
+	Name     string                   `json:"name"`
+	Position ChargingLocationPosition `json:"position"`
+}
+
+// validate returns an error if the POI has no name, its coordinates are unset (0, 0) or out of range
+func (p POI) validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("poi name must not be empty")
+	}
+	if err := p.Position.validate(); err != nil {
+		return fmt.Errorf("poi %w", err)
+	}
+	return nil
+}
+
+// SendPOI sends poi to the navigation system of the car
+func (v *VehiclesService) SendPOI(ctx context.Context, vin string, poi *POI) (status *VehicleServiceStatus, err error) {
+	if vin == "" {
+		return nil, fmt.Errorf("vin must not be empty")
+	}
+	if poi == nil {
+		return nil, fmt.Errorf("poi must not be empty")
+	}
+	if err = poi.validate(); err != nil {
+		return nil, err
+	}
+	url := v.client.MakeURL(v.Endpoint, vin, "pois")
+	if _, err = v.client.Request.Post(ctx, url, poi, &status); err != nil {
+		return nil, err
+	}
+	status.client = v.client
+	return
+}
+
+func (v Vehicle) IsSendPOISupported() bool {
+	return len(v.Attributes.SendPOIToVehicleVersionsSupported) > 0
+}
+
+func (v Vehicle) SendPOI(ctx context.Context, poi *POI) (op *Operation, err error) {
+	if !v.IsSendPOISupported() {
+		return nil, fmt.Errorf("sending points of interest is not supported by %s [%s]: %w", v.Attributes.RegistrationNumber, v.Attributes.Vin, ErrVehicleUnsupported)
+	}
+	vss, err := v.client.Vehicles.SendPOI(ctx, v.VehicleID, poi)
+	if err != nil {
+		return nil, err
+	}
+	return v.client.Vehicles.Track(ctx, vss, nil), nil
+}
@@ -0,0 +1,69 @@
+package vocdriver_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+	"github.com/theriverman/VolvoOnCall/voctest"
+)
+
+func TestVehicle_SendPOI(t *testing.T) {
+	srv, client, vehicle := newTestVehicle(t, nil, vocdriver.WithPollPolicy(&vocdriver.PollPolicy{InitialInterval: 50 * time.Millisecond, Timeout: 5 * time.Second}))
+	ctx := context.Background()
+	poi := vocdriver.POI{
+		Name:     "Depot",
+		Position: vocdriver.ChargingLocationPosition{Latitude: 57.708870, Longitude: 11.974560, City: "Göteborg"},
+	}
+	op, err := vehicle.SendPOI(ctx, &poi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = op.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if st := op.Status(); st.ServiceType != "pois" || !st.Status.IsSuccess() {
+		t.Errorf("unexpected status: %s %s", st.ServiceType, st.Status)
+	}
+	if v, _ := srv.Handler.Vehicle(voctest.VIN); len(v.POIs) != 1 || v.POIs[0] != poi {
+		t.Errorf("unexpected POIs received by the car: %+v", v.POIs)
+	}
+
+	for _, invalid := range []vocdriver.POI{
+		{Position: poi.Position},
+		{Name: "Nowhere"},
+		{Name: "Nowhere", Position: vocdriver.ChargingLocationPosition{Latitude: 91}},
+		{Name: "Nowhere", Position: vocdriver.ChargingLocationPosition{Longitude: -181}},
+	} {
+		if _, err = client.Vehicles.SendPOI(ctx, voctest.VIN, &invalid); err == nil {
+			t.Errorf("expected %+v to be rejected", invalid)
+		}
+	}
+	if _, err = vehicle.SendPOI(ctx, nil); err == nil {
+		t.Error("expected a nil POI to be rejected")
+	}
+}
+
+func TestVehicle_SendPOIUnsupported(t *testing.T) {
+	fixtures := voctest.DefaultFixtures()
+	fixtures.Vehicles[0].Attributes.SendPOIToVehicleVersionsSupported = []string{}
+	_, _, vehicle := newTestVehicle(t, fixtures)
+	ctx := context.Background()
+	if _, err := vehicle.SendPOI(ctx, &vocdriver.POI{Name: "Depot"}); !errors.Is(err, vocdriver.ErrVehicleUnsupported) {
+		t.Errorf("expected ErrVehicleUnsupported, got %v", err)
+	}
+}
+
+func TestPOI_ZeroCoordinateIsSent(t *testing.T) {
+	data, err := json.Marshal(vocdriver.POI{Name: "Greenwich", Position: vocdriver.ChargingLocationPosition{Latitude: 51.4779}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"longitude":0`) {
+		t.Errorf("a zero coordinate must be sent, got %s", data)
+	}
+}
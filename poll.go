@@ -0,0 +1,98 @@
+package vocdriver
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PollPolicy configures how the Service API is polled while waiting for a remote command to finish
+type PollPolicy struct {
+	InitialInterval time.Duration // delay before the first refresh of the status. Zero means the one of DefaultPollPolicy
+	Multiplier      float64       // the interval is multiplied by it after each poll. Values <= 1 keep it constant
+	MaxInterval     time.Duration // caps the interval. Zero means no limit
+	Timeout         time.Duration // wall-clock deadline of the whole evaluation. Zero means no deadline besides the context's
+}
+
+// DefaultPollPolicy polls after 1s, backs off by 1.5x up to 5s between polls and gives up after 30s
+//
+// Unlocks wait for the vehicle's unlockTimeFrame instead, as the car only reports success once the tailgate was opened
+func DefaultPollPolicy() *PollPolicy {
+	return &PollPolicy{
+		InitialInterval: 1 * time.Second,
+		Multiplier:      1.5,
+		MaxInterval:     5 * time.Second,
+		Timeout:         30 * time.Second,
+	}
+}
+
+// validate returns an error if any of the values of the policy is negative
+func (p *PollPolicy) validate() error {
+	if p.InitialInterval < 0 || p.Multiplier < 0 || p.MaxInterval < 0 || p.Timeout < 0 {
+		return fmt.Errorf("poll policy values must not be negative: %+v", *p)
+	}
+	return nil
+}
+
+// withDefaults returns a copy of the policy with its unset InitialInterval taken from DefaultPollPolicy,
+// so a policy only setting the Timeout does not poll in a tight loop
+func (p *PollPolicy) withDefaults() *PollPolicy {
+	policy := *p
+	if policy.InitialInterval == 0 {
+		policy.InitialInterval = DefaultPollPolicy().InitialInterval
+	}
+	return &policy
+}
+
+// next returns the interval following interval
+func (p *PollPolicy) next(interval time.Duration) time.Duration {
+	if p.Multiplier > 1 {
+		interval = time.Duration(float64(interval) * p.Multiplier)
+	}
+	if p.MaxInterval > 0 && interval > p.MaxInterval {
+		interval = p.MaxInterval
+	}
+	return interval
+}
+
+// pollPolicy returns the policy used to evaluate vss when no policy is passed explicitly:
+// the PollPolicy of the Client (or DefaultPollPolicy), with the Timeout extended to the unlockTimeFrame for unlocks
+func (v *VehiclesService) pollPolicy(ctx context.Context, vss *VehicleServiceStatus) (*PollPolicy, error) {
+	policy := DefaultPollPolicy()
+	if v.client.PollPolicy != nil {
+		p := *v.client.PollPolicy
+		policy = &p
+	}
+	if vss.ServiceType != ServiceTypeUnlock || policy.Timeout == 0 {
+		return policy, nil
+	}
+	attributes := v.client.knownAttributes(vss.VehicleID)
+	if attributes == nil {
+		var err error
+		if attributes, err = v.GetVehicleAttributesByVIN(ctx, vss.VehicleID); err != nil {
+			return nil, fmt.Errorf("failed to retrieve vehicle attributes for %s: %w", vss.VehicleID, err)
+		}
+	}
+	if unlockTimeFrame := time.Duration(attributes.UnlockTimeFrame) * time.Second; unlockTimeFrame > policy.Timeout {
+		policy.Timeout = unlockTimeFrame
+		v.client.logger().Log(LevelInfo, "timeout increased to match the vehicle's unlockTimeFrame", "vin", vss.VehicleID, "timeout", policy.Timeout)
+	}
+	return policy, nil
+}
+
+// knownAttributes returns the attributes of vin retrieved earlier by the Client, or nil
+func (c *Client) knownAttributes(vin string) *VehicleAttributes {
+	c.attributesMu.Lock()
+	defer c.attributesMu.Unlock()
+	return c.attributes[vin]
+}
+
+// rememberAttributes keeps attributes so e.g. the unlock time frame can be read without another request
+func (c *Client) rememberAttributes(vin string, attributes *VehicleAttributes) {
+	c.attributesMu.Lock()
+	defer c.attributesMu.Unlock()
+	if c.attributes == nil {
+		c.attributes = map[string]*VehicleAttributes{}
+	}
+	c.attributes[vin] = attributes
+}
@@ -0,0 +1,102 @@
+package vocdriver_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+	"github.com/theriverman/VolvoOnCall/voctest"
+)
+
+func TestEvaluateServiceStatus_PollPolicy(t *testing.T) {
+	srv, client := newTestClient(t, nil)
+	srv.Handler.SetScenario(voctest.Scenario{CompletionDelay: voctest.Duration(2 * time.Second)})
+	ctx := context.Background()
+
+	vss, err := client.Vehicles.LockVehicle(ctx, voctest.VIN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	err = client.Vehicles.EvaluateServiceStatus(ctx, vss, &vocdriver.PollPolicy{InitialInterval: 50 * time.Millisecond, Timeout: 300 * time.Millisecond})
+	if err == nil || !strings.Contains(err.Error(), "timeout") {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("the wall-clock timeout was not respected: %s", elapsed)
+	}
+
+	// 50ms, 100ms, 200ms, 400ms, 400ms... polls until the command completes 2s after it was sent (~1.7s from here)
+	before := len(srv.Handler.Requests())
+	err = client.Vehicles.EvaluateServiceStatus(ctx, vss, &vocdriver.PollPolicy{InitialInterval: 50 * time.Millisecond, Multiplier: 2, MaxInterval: 400 * time.Millisecond, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	polls := 0
+	for _, r := range srv.Handler.Requests()[before:] {
+		if strings.Contains(r.Path, "/services/") {
+			polls++
+		}
+	}
+	if polls < 4 || polls > 10 {
+		t.Errorf("unexpected number of polls with backoff: %d", polls)
+	}
+}
+
+func TestEvaluateServiceStatus_UnlockTimeFrameFromKnownAttributes(t *testing.T) {
+	fixtures := voctest.DefaultFixtures()
+	fixtures.Vehicles[0].Attributes.UnlockTimeFrame = 1
+	srv, client := newTestClient(t, fixtures, vocdriver.WithPollPolicy(&vocdriver.PollPolicy{InitialInterval: 100 * time.Millisecond, Timeout: 200 * time.Millisecond}))
+	srv.Handler.SetScenario(voctest.Scenario{Offline: true})
+	ctx := context.Background()
+	if _, err := client.Vehicles.GetVehicleAttributesByVIN(ctx, voctest.VIN); err != nil {
+		t.Fatal(err)
+	}
+
+	vss, err := client.Vehicles.UnlockVehicle(ctx, voctest.VIN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if err = client.Vehicles.EvaluateServiceStatusAuto(ctx, vss); err == nil || !strings.Contains(err.Error(), "timeout (1s)") {
+		t.Fatalf("expected the unlock time frame to be used as timeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("gave up before the unlock time frame: %s", elapsed)
+	}
+	attributeRequests := 0
+	for _, r := range srv.Handler.Requests() {
+		if strings.HasSuffix(r.Path, "/attributes") {
+			attributeRequests++
+		}
+	}
+	if attributeRequests != 1 {
+		t.Errorf("expected the known attributes to be reused, got %d requests", attributeRequests)
+	}
+}
+
+func TestEvaluateServiceStatus_TimeoutOnlyPolicy(t *testing.T) {
+	srv, client := newTestClient(t, nil)
+	srv.Handler.SetScenario(voctest.Scenario{Offline: true})
+	ctx := context.Background()
+
+	vss, err := client.Vehicles.LockVehicle(ctx, voctest.VIN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the InitialInterval of DefaultPollPolicy (1s) is used, so the status is not refreshed before the timeout
+	if err = client.Vehicles.EvaluateServiceStatus(ctx, vss, &vocdriver.PollPolicy{Timeout: 300 * time.Millisecond}); err == nil || !strings.Contains(err.Error(), "timeout") {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+	polls := 0
+	for _, r := range srv.Handler.Requests() {
+		if strings.Contains(r.Path, "/services/") {
+			polls++
+		}
+	}
+	if polls != 0 {
+		t.Errorf("expected no polls within the timeout, got %d", polls)
+	}
+}
@@ -0,0 +1,99 @@
+package vocdriver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Region is a Volvo On Call service region. Its value is the code embedded in the API host (vocapi-<code>.wirelesscar.net)
+//
+// The Volvo On Call app lets you select Africa, Asia, China Mainland, Europe, North America, Oceania or South America,
+// but only North America and China Mainland have dedicated hosts. Every other continent is served by RegionEurope
+type Region string
+
+const (
+	RegionEurope       Region = ""     // vocapi.wirelesscar.net [default]
+	RegionNorthAmerica Region = "na"   // vocapi-na.wirelesscar.net
+	RegionChina        Region = "cn"   // vocapi-cn.wirelesscar.net
+	RegionAuto         Region = "auto" // probes every region until one accepts the credentials. See Client.DetectRegion
+)
+
+// Regions lists the regions probed by Client.DetectRegion, in order
+var Regions = []Region{RegionEurope, RegionNorthAmerica, RegionChina}
+
+// regionNames maps the lower-cased friendly names and codes accepted by ParseRegion to a Region
+var regionNames = map[string]Region{
+	"":               RegionEurope,
+	"eu":             RegionEurope,
+	"europe":         RegionEurope,
+	"africa":         RegionEurope,
+	"asia":           RegionEurope,
+	"oceania":        RegionEurope,
+	"south america":  RegionEurope,
+	"na":             RegionNorthAmerica,
+	"north america":  RegionNorthAmerica,
+	"us":             RegionNorthAmerica,
+	"cn":             RegionChina,
+	"china":          RegionChina,
+	"china mainland": RegionChina,
+	"auto":           RegionAuto,
+}
+
+// ParseRegion accepts a region code (e.g.: na, cn) or a friendly name (e.g.: North America, China Mainland, Oceania)
+func ParseRegion(s string) (Region, error) {
+	name := strings.Join(strings.Fields(strings.ToLower(strings.ReplaceAll(s, "_", " "))), " ")
+	if r, ok := regionNames[name]; ok {
+		return r, nil
+	}
+	return "", fmt.Errorf("unknown region %q: use one of europe, na (North America), cn (China Mainland) or auto", s)
+}
+
+// String returns the friendly name of the region
+func (r Region) String() string {
+	switch r {
+	case RegionEurope:
+		return "Europe"
+	case RegionNorthAmerica:
+		return "North America"
+	case RegionChina:
+		return "China Mainland"
+	case RegionAuto:
+		return "Auto"
+	default:
+		return string(r)
+	}
+}
+
+// BaseURL returns the API URL serving the region. RegionAuto resolves to RegionEurope until detected
+func (r Region) BaseURL() string {
+	if r == RegionEurope || r == RegionAuto {
+		return fmt.Sprintf(BaseUrl, "")
+	}
+	return fmt.Sprintf(BaseUrl, "-"+string(r))
+}
+
+// DetectRegion probes /customeraccounts in every region of Regions and switches the Client to the first one accepting its credentials
+//
+// The Client must be authenticated. Clients created with a custom BaseURL cannot be switched between regions
+func (c *Client) DetectRegion(ctx context.Context) (region Region, err error) {
+	if c.BaseURL != "" {
+		return "", fmt.Errorf("cannot detect the region of a client with a custom base URL")
+	}
+	for _, r := range Regions {
+		if err = ctx.Err(); err != nil {
+			return "", err
+		}
+		account := CustomerAccount{}
+		_, err = c.Request.Get(ctx, r.BaseURL()+"/customeraccounts", &account)
+		if err != nil {
+			c.logger().Log(LevelDebug, "region probe failed", "region", r, "error", err)
+			continue
+		}
+		c.logger().Log(LevelInfo, "detected region", "region", r)
+		c.ServiceRegion = r
+		c.apiUrl = r.BaseURL()
+		return r, nil
+	}
+	return "", fmt.Errorf("no region accepted the credentials: %w", err)
+}
@@ -0,0 +1,105 @@
+package vocdriver_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+	"github.com/theriverman/VolvoOnCall/voctest"
+)
+
+func TestParseRegion(t *testing.T) {
+	for input, expected := range map[string]vocdriver.Region{
+		"":               vocdriver.RegionEurope,
+		"Europe":         vocdriver.RegionEurope,
+		"Oceania":        vocdriver.RegionEurope,
+		"na":             vocdriver.RegionNorthAmerica,
+		"North  America": vocdriver.RegionNorthAmerica,
+		"CN":             vocdriver.RegionChina,
+		"china_mainland": vocdriver.RegionChina,
+		"auto":           vocdriver.RegionAuto,
+	} {
+		r, err := vocdriver.ParseRegion(input)
+		if err != nil || r != expected {
+			t.Errorf("ParseRegion(%q) = %q, %v; expected %q", input, r, err, expected)
+		}
+	}
+	if _, err := vocdriver.ParseRegion("atlantis"); err == nil {
+		t.Error("expected an error for an unknown region")
+	}
+}
+
+func TestRegion_BaseURL(t *testing.T) {
+	for region, expected := range map[vocdriver.Region]string{
+		vocdriver.RegionEurope:       "https://vocapi.wirelesscar.net/customerapi/rest/v3.0",
+		vocdriver.RegionNorthAmerica: "https://vocapi-na.wirelesscar.net/customerapi/rest/v3.0",
+		vocdriver.RegionChina:        "https://vocapi-cn.wirelesscar.net/customerapi/rest/v3.0",
+	} {
+		client, err := vocdriver.NewClient(vocdriver.WithRegion(region))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := client.MakeURL("customeraccounts"); got != expected+"/customeraccounts" {
+			t.Errorf("%s: unexpected URL %s", region, got)
+		}
+	}
+}
+
+// hostRewriter sends the requests addressed to the real VOC hosts to fake servers
+type hostRewriter map[string]*voctest.Server
+
+func (h hostRewriter) RoundTrip(req *http.Request) (*http.Response, error) {
+	srv, ok := h[req.URL.Host]
+	if !ok {
+		return nil, &url.Error{Op: req.Method, URL: req.URL.String(), Err: http.ErrServerClosed}
+	}
+	u, _ := url.Parse(srv.URL)
+	req = req.Clone(req.Context())
+	req.URL.Scheme, req.URL.Host = u.Scheme, u.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestClient_DetectRegion(t *testing.T) {
+	other := voctest.DefaultFixtures()
+	other.Username = "someone.else@example.com"
+	europe := voctest.NewServer(other)
+	defer europe.Close()
+	northAmerica := voctest.NewServer(nil)
+	defer northAmerica.Close()
+
+	client, err := vocdriver.NewClient(
+		vocdriver.WithRegion(vocdriver.RegionAuto),
+		vocdriver.WithCredentials(voctest.Username, voctest.Password),
+		vocdriver.WithHTTPClient(&http.Client{Transport: hostRewriter{
+			"vocapi.wirelesscar.net":    europe,
+			"vocapi-na.wirelesscar.net": northAmerica,
+		}}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.ServiceRegion != vocdriver.RegionNorthAmerica {
+		t.Errorf("expected North America, got %s", client.ServiceRegion)
+	}
+	if _, err = client.CustomerAccount.GetAccount(context.Background()); err != nil {
+		t.Error(err)
+	}
+
+	if _, err = vocdriver.NewClient(vocdriver.WithRegion(vocdriver.RegionAuto)); err == nil {
+		t.Error("expected an error detecting the region without credentials")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = vocdriver.NewClientContext(ctx,
+		vocdriver.WithRegion(vocdriver.RegionAuto),
+		vocdriver.WithCredentials(voctest.Username, voctest.Password),
+		vocdriver.WithHTTPClient(&http.Client{Transport: hostRewriter{"vocapi.wirelesscar.net": europe}}),
+	)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the detection to be canceled, got %v", err)
+	}
+}
@@ -2,10 +2,12 @@ package vocdriver
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 )
 
 // Evaluation Tools
@@ -35,10 +37,11 @@ func SuccessfulHTTPRequest(response *http.Response) bool {
 
 // Get a handler for composing a new HTTP GET request
 //
+//   - ctx carries the deadline and cancellation signal of the request
 //   - URL must be an absolute (full) URL to the desired endpoint
 //   - ResponseBody must be a pointer to a struct representing the fields returned by Taiga
-func (s *RequestService) Get(url string, responseBody interface{}) (*http.Response, error) {
-	return newRawRequest("GET", s.client, responseBody, url, nil)
+func (s *RequestService) Get(ctx context.Context, url string, responseBody interface{}) (*http.Response, error) {
+	return newRawRequest(ctx, "GET", s.client, responseBody, url, nil)
 }
 
 // Head a handler for composing a new HTTP HEAD request
@@ -48,36 +51,40 @@ func (s *RequestService) Head() {
 
 // Post a handler for composing a new HTTP POST request
 //
+//   - ctx carries the deadline and cancellation signal of the request
 //   - URL must be an absolute (full) URL to the desired endpoint
 //   - Payload must be a pointer to a complete struct which will be sent to Taiga
 //   - ResponseBody must be a pointer to a struct representing the fields returned by Taiga
-func (s *RequestService) Post(url string, payload interface{}, responseBody interface{}) (*http.Response, error) {
-	return newRawRequest("POST", s.client, responseBody, url, payload)
+func (s *RequestService) Post(ctx context.Context, url string, payload interface{}, responseBody interface{}) (*http.Response, error) {
+	return newRawRequest(ctx, "POST", s.client, responseBody, url, payload)
 }
 
 // Put a handler for composing a new HTTP PUT request
 //
+//   - ctx carries the deadline and cancellation signal of the request
 //   - URL must be an absolute (full) URL to the desired endpoint
 //   - Payload must be a pointer to a complete struct which will be sent to Taiga
 //   - ResponseBody must be a pointer to a struct representing the fields returned by Taiga
-func (s *RequestService) Put(url string, payload interface{}, responseBody interface{}) (*http.Response, error) {
-	return newRawRequest("PUT", s.client, responseBody, url, payload)
+func (s *RequestService) Put(ctx context.Context, url string, payload interface{}, responseBody interface{}) (*http.Response, error) {
+	return newRawRequest(ctx, "PUT", s.client, responseBody, url, payload)
 }
 
 // Patch a handler for composing a new HTTP PATCH request
 //
+//   - ctx carries the deadline and cancellation signal of the request
 //   - URL must be an absolute (full) URL to the desired endpoint
 //   - Payload must be a pointer to a complete struct which will be sent to Taiga
 //   - ResponseBody must be a pointer to a struct representing the fields returned by Taiga
-func (s *RequestService) Patch(url string, payload interface{}, responseBody interface{}) (*http.Response, error) {
-	return newRawRequest("PATCH", s.client, responseBody, url, payload)
+func (s *RequestService) Patch(ctx context.Context, url string, payload interface{}, responseBody interface{}) (*http.Response, error) {
+	return newRawRequest(ctx, "PATCH", s.client, responseBody, url, payload)
 }
 
 // Delete a handler for composing a new HTTP DELETE request
 //
+//   - ctx carries the deadline and cancellation signal of the request
 //   - URL must be an absolute (full) URL to the desired endpoint
-func (s *RequestService) Delete(url string) (*http.Response, error) {
-	return newRawRequest("DELETE", s.client, nil, url, nil)
+func (s *RequestService) Delete(ctx context.Context, url string) (*http.Response, error) {
+	return newRawRequest(ctx, "DELETE", s.client, nil, url, nil)
 }
 
 // Connect a handler for composing a new HTTP CONNECT request
@@ -95,64 +102,97 @@ func (s *RequestService) Trace() {
 	panic("TRACE requests are not implemented")
 }
 
-func newRawRequest(requestType string, c *Client, responseBody interface{}, url string, payload interface{}) (*http.Response, error) {
-	// New RAW request
-	var request *http.Request
-	var err error
+func newRawRequest(ctx context.Context, requestType string, c *Client, responseBody interface{}, url string, payload interface{}) (*http.Response, error) {
+	// Marshal the payload only once so it can be re-sent by subsequent attempts
+	var body []byte
+	if payload != nil {
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return nil, err
+		}
+	}
 
-	switch {
-	case payload == nil:
-		request, err = http.NewRequest(requestType, url, nil)
+	// Resolve the credentials once per request so rotated passwords take effect
+	header := c.Headers.Clone()
+	if c.Credentials != nil {
+		username, password, err := c.Credentials.Credentials(ctx)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("resolving credentials: %w", err)
+		}
+		header.Set("Authorization", "Basic "+basicAuth(username, password))
+	}
+
+	logger := c.logger()
+	for attempt := 1; ; attempt++ {
+		logger.Log(LevelDebug, "sending request", "method", requestType, "url", url, "attempt", attempt, "headers", redactSecretHeaders(header))
+		start := time.Now()
+		resp, rawResponseBody, err := doRawRequest(ctx, requestType, c.HTTPClient, header, url, body)
+		latency := time.Since(start)
+		if err == nil && !SuccessfulHTTPRequest(resp) {
+			err = newAPIError(resp, rawResponseBody)
+		}
+		switch {
+		case resp != nil:
+			logger.Log(LevelDebug, "received response", "method", requestType, "url", url, "status", resp.StatusCode, "latency", latency)
+		case err != nil:
+			logger.Log(LevelWarn, "request failed", "method", requestType, "url", url, "latency", latency, "error", err)
 		}
 
-	case payload != nil:
-		body, err := json.Marshal(payload)
+		delay, retry := c.RetryPolicy.next(ctx, attempt, requestType, resp, err)
+		if c.RetryPolicy != nil && c.RetryPolicy.OnAttempt != nil {
+			ra := RetryAttempt{Attempt: attempt, Method: requestType, URL: url, Err: err, Retry: retry, Delay: delay}
+			if resp != nil {
+				ra.StatusCode = resp.StatusCode
+			}
+			c.RetryPolicy.OnAttempt(ra)
+		}
+		if retry {
+			logger.Log(LevelInfo, "retrying request", "method", requestType, "url", url, "attempt", attempt, "delay", delay, "error", err)
+			if err = sleepContext(ctx, delay); err != nil {
+				return nil, err
+			}
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
-		request, err = http.NewRequest(requestType, url, bytes.NewBuffer(body))
-		if err != nil {
+
+		// There's no body returned for 204 responses
+		if resp.StatusCode == http.StatusNoContent || len(rawResponseBody) == 0 {
+			return resp, nil
+		}
+		// We expect content so convert response JSON string to struct
+		if err = json.Unmarshal(rawResponseBody, &responseBody); err != nil {
 			return nil, err
 		}
+		return resp, nil
+	}
+}
 
-	default:
-		return nil, fmt.Errorf("failed to build request because the received payload could not be processed")
+// doRawRequest executes a single HTTP request and returns the response along with its fully read body
+func doRawRequest(ctx context.Context, requestType string, httpClient *http.Client, header http.Header, url string, body []byte) (*http.Response, []byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	request, err := http.NewRequestWithContext(ctx, requestType, url, reader)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Load Headers
-	for key, values := range *c.Headers {
-		for _, value := range values {
-			request.Header.Add(key, value)
-		}
-	}
+	request.Header = header.Clone()
 
 	// Execute request
-	resp, err := c.HTTPClient.Do(request)
+	resp, err := httpClient.Do(request)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer resp.Body.Close()
 
-	// Evaluate response status code
-	if SuccessfulHTTPRequest(resp) {
-		if resp.StatusCode == http.StatusNoContent { //  There's no body returned for 204 responses
-			return resp, nil
-		}
-		// We expect content so convert response JSON string to struct
-		decoder := json.NewDecoder(resp.Body)
-		err = decoder.Decode(&responseBody)
-		if err != nil {
-			return nil, err
-		}
-		return resp, nil
-	}
-
 	rawResponseBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return resp, nil, err
 	}
-
-	return nil, fmt.Errorf(string(rawResponseBody))
+	return resp, rawResponseBody, nil
 }
@@ -0,0 +1,145 @@
+package vocdriver
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RetryPolicy configures how RequestService retries requests failing with a transient error
+//
+// Transient errors are network errors (e.g.: connection resets), 429 Too Many Requests and
+// 500, 502, 503, 504 responses. Only GET requests are retried unless RetryPOST is set.
+type RetryPolicy struct {
+	MaxAttempts    int           // total number of attempts including the first one. 0 or 1 disables retries
+	InitialBackoff time.Duration // delay before the first retry
+	MaxBackoff     time.Duration // upper limit of a single delay. A longer Retry-After stops retrying and returns the error
+	Multiplier     float64       // growth factor of the delay between consecutive retries (default: 2)
+	Jitter         float64       // fraction [0, 1] of each delay which is randomised to spread out retries
+	RetryPOST      bool          // remote commands (e.g.: lock, start engine) are not idempotent, so they must be opted in explicitly
+
+	// OnAttempt is called after every attempt (including the last one) if not nil
+	OnAttempt func(attempt RetryAttempt)
+}
+
+// RetryAttempt describes the outcome of a single attempt passed to RetryPolicy.OnAttempt
+type RetryAttempt struct {
+	Attempt    int           // 1-based number of the attempt
+	Method     string        // HTTP method of the request
+	URL        string        // absolute URL of the request
+	StatusCode int           // 0 if no response was received
+	Err        error         // nil if the attempt succeeded
+	Retry      bool          // true if another attempt follows
+	Delay      time.Duration // time waited before the next attempt
+}
+
+// DefaultRetryPolicy returns a RetryPolicy retrying GET requests up to 3 times (4 attempts in total)
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+var retryableStatusCodes = [...]int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+var (
+	jitterRand   = rand.New(rand.NewSource(time.Now().UnixNano()))
+	jitterRandMu sync.Mutex
+)
+
+// next decides whether the request must be attempted again and how long to wait before doing so
+func (p *RetryPolicy) next(ctx context.Context, attempt int, method string, resp *http.Response, err error) (time.Duration, bool) {
+	if p == nil || err == nil || attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return 0, false
+	}
+	if method != http.MethodGet && !(method == http.MethodPost && p.RetryPOST) {
+		return 0, false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if !isRetryableStatusCode(apiErr.StatusCode) {
+			return 0, false
+		}
+		if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			// retrying earlier than asked would likely fail again, so give up instead of waiting longer than allowed
+			if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+				return 0, false
+			}
+			return delay, true
+		}
+	}
+	return p.backoff(attempt), true
+}
+
+// backoff returns the exponential delay following the given attempt with jitter applied
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		jitterRandMu.Lock()
+		delay -= delay * math.Min(p.Jitter, 1) * jitterRand.Float64()
+		jitterRandMu.Unlock()
+	}
+	return time.Duration(delay)
+}
+
+func isRetryableStatusCode(statusCode int) bool {
+	for _, code := range retryableStatusCodes {
+		if statusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRetryAfter parses the value of a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		delay := time.Until(t)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+// sleepContext waits for the given duration or until ctx is cancelled, whichever happens first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,110 @@
+package vocdriver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		switch {
+		case r.Method == http.MethodGet && n == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.Method == http.MethodGet && n == 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case r.Method == http.MethodGet:
+			fmt.Fprint(w, `{"username":"john.doe"}`)
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	var attempts []RetryAttempt
+	client := &Client{BaseURL: srv.URL}
+	if err := client.Initialise(); err != nil {
+		t.Fatal(err)
+	}
+	client.RetryPolicy = &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		Jitter:         0.5,
+		OnAttempt:      func(attempt RetryAttempt) { attempts = append(attempts, attempt) },
+	}
+	ctx := context.Background()
+
+	account, err := client.CustomerAccount.GetAccount(ctx)
+	if err != nil {
+		t.Fatalf("expected the third attempt to succeed: %v", err)
+	}
+	if account.Username != "john.doe" {
+		t.Errorf("unexpected account: %+v", account)
+	}
+	if len(attempts) != 3 || attempts[0].StatusCode != http.StatusServiceUnavailable || !attempts[1].Retry || attempts[1].Delay != 0 || attempts[2].Retry {
+		t.Errorf("unexpected attempts: %+v", attempts)
+	}
+
+	// remote commands are not retried unless opted in
+	atomic.StoreInt32(&calls, 0)
+	if _, err = client.Vehicles.LockVehicle(ctx, "YV1ABCDEFGH123456"); !hasStatusCode(err, http.StatusBadGateway) {
+		t.Fatalf("expected 502, got %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("expected a single POST attempt, got %d", n)
+	}
+
+	client.RetryPolicy.RetryPOST = true
+	atomic.StoreInt32(&calls, 0)
+	if _, err = client.Vehicles.LockVehicle(ctx, "YV1ABCDEFGH123456"); !hasStatusCode(err, http.StatusBadGateway) {
+		t.Fatalf("expected 502, got %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 3 {
+		t.Errorf("expected 3 POST attempts, got %d", n)
+	}
+}
+
+func TestRetryPolicy_RetryAfterExceedsMaxBackoff(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	client := &Client{BaseURL: srv.URL}
+	if err := client.Initialise(); err != nil {
+		t.Fatal(err)
+	}
+	client.RetryPolicy = &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Second}
+	start := time.Now()
+	if _, err := client.CustomerAccount.GetAccount(context.Background()); !hasStatusCode(err, http.StatusTooManyRequests) {
+		t.Fatalf("expected 429, got %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("expected a single attempt, got %d", n)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected to fail fast, took %s", elapsed)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d, ok := parseRetryAfter("7"); !ok || d != 7*time.Second {
+		t.Errorf("unexpected delay for seconds: %v, %t", d, ok)
+	}
+	if d, ok := parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)); !ok || d < 59*time.Minute {
+		t.Errorf("unexpected delay for HTTP date: %v, %t", d, ok)
+	}
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Error("expected invalid Retry-After to be rejected")
+	}
+}
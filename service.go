@@ -0,0 +1,63 @@
+package vocdriver
+
+// ServiceStatus is the state of an asynchronous remote service (e.g.: a lock command) reported by the Service API
+type ServiceStatus string
+
+const (
+	ServiceStatusStarted          ServiceStatus = "Started"          // accepted by the VOC API
+	ServiceStatusMessageDelivered ServiceStatus = "MessageDelivered" // delivered to the car
+	ServiceStatusSuccessful       ServiceStatus = "Successful"
+	ServiceStatusFailed           ServiceStatus = "Failed" // see VehicleServiceStatus.FailureReason
+)
+
+// IsTerminal returns true unless the service is still in progress. Unknown statuses are considered terminal
+func (s ServiceStatus) IsTerminal() bool {
+	return s != ServiceStatusStarted && s != ServiceStatusMessageDelivered
+}
+
+// IsSuccess returns true if the service finished successfully
+func (s ServiceStatus) IsSuccess() bool {
+	return s == ServiceStatusSuccessful
+}
+
+// ServiceType identifies the remote service behind a VehicleServiceStatus
+//
+// The API does not document its codes. Only the codes observed in its responses (the entries of the former
+// ServiceTypeMap) have a constant; the codes of the other services are kept as they are returned
+type ServiceType string
+
+const (
+	ServiceTypeLock        ServiceType = "RDL"
+	ServiceTypeUnlock      ServiceType = "RDU"
+	ServiceTypeBlinkLights ServiceType = "RHBLF"
+)
+
+// serviceTypeNames are the human names of the known service types
+var serviceTypeNames = map[ServiceType]string{
+	ServiceTypeLock:        "Lock Vehicle",
+	ServiceTypeUnlock:      "Unlock Vehicle",
+	ServiceTypeBlinkLights: "Blink Lights",
+}
+
+// Name returns the human name of the service type, e.g.: Lock Vehicle. Unknown types return their raw code
+func (t ServiceType) Name() string {
+	if name, ok := serviceTypeNames[t]; ok {
+		return name
+	}
+	return string(t)
+}
+
+func (t ServiceType) String() string {
+	return t.Name()
+}
+
+// ServiceTypeMap maps the codes of the known service types to their human names
+//
+// Deprecated: use ServiceType.Name
+var ServiceTypeMap = func() map[string]string {
+	m := make(map[string]string, len(serviceTypeNames))
+	for t, name := range serviceTypeNames {
+		m[string(t)] = name
+	}
+	return m
+}()
@@ -0,0 +1,71 @@
+package vocdriver_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+	"github.com/theriverman/VolvoOnCall/voctest"
+)
+
+func TestServiceStatus(t *testing.T) {
+	for status, expected := range map[vocdriver.ServiceStatus][2]bool{
+		vocdriver.ServiceStatusStarted:          {false, false},
+		vocdriver.ServiceStatusMessageDelivered: {false, false},
+		vocdriver.ServiceStatusSuccessful:       {true, true},
+		vocdriver.ServiceStatusFailed:           {true, false},
+		"SomethingElse":                         {true, false},
+	} {
+		if status.IsTerminal() != expected[0] || status.IsSuccess() != expected[1] {
+			t.Errorf("%s: unexpected IsTerminal %t, IsSuccess %t", status, status.IsTerminal(), status.IsSuccess())
+		}
+	}
+}
+
+func TestServiceType_Name(t *testing.T) {
+	if name := vocdriver.ServiceTypeLock.Name(); name != "Lock Vehicle" {
+		t.Errorf("unexpected name: %s", name)
+	}
+	if name := vocdriver.ServiceType("XYZ").Name(); name != "XYZ" {
+		t.Errorf("unknown service types should fall back to their code, got %s", name)
+	}
+}
+
+func TestEvaluateServiceStatus_FailureNamesService(t *testing.T) {
+	srv, client := newTestClient(t, nil)
+	srv.Handler.SetScenario(voctest.Scenario{Failures: map[string]string{"RDL": "DoorOpen"}})
+	ctx := context.Background()
+	vss, err := client.Vehicles.LockVehicle(ctx, voctest.VIN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vss.ServiceType != vocdriver.ServiceTypeLock {
+		t.Errorf("unexpected service type %q", vss.ServiceType)
+	}
+	err = client.Vehicles.EvaluateServiceStatus(ctx, vss, &vocdriver.PollPolicy{InitialInterval: 100 * time.Millisecond, Timeout: 5 * time.Second})
+	if err == nil || !strings.Contains(err.Error(), "Lock Vehicle") || !strings.Contains(err.Error(), "DoorOpen") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestStartEngine_Runtime(t *testing.T) {
+	srv, client := newTestClient(t, nil)
+	ctx := context.Background()
+	for _, runtime := range []int{0, vocdriver.MaxEngineRuntime + 1} {
+		if _, err := client.Vehicles.StartEngine(ctx, voctest.VIN, runtime); err == nil {
+			t.Errorf("expected runtime %d to be rejected", runtime)
+		}
+	}
+	if len(srv.Handler.Requests()) != 0 {
+		t.Error("invalid runtimes must not reach the API")
+	}
+	if _, err := client.Vehicles.StartEngine(ctx, voctest.VIN, 7); err != nil {
+		t.Fatal(err)
+	}
+	requests := srv.Handler.Requests()
+	if len(requests) != 1 || !strings.HasSuffix(requests[0].Path, "/engine/start") || strings.TrimSpace(string(requests[0].Body)) != `{"runtime":7}` {
+		t.Errorf("unexpected requests: %+v", requests)
+	}
+}
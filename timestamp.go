@@ -0,0 +1,86 @@
+package vocdriver
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// TimestampLayout is the format of the timestamps produced by the VOC API, e.g.: 2022-11-20T08:15:00+0000
+const TimestampLayout = "2006-01-02T15:04:05-0700"
+
+// timestampLayouts are tried in order when parsing a Timestamp. Fractional seconds are accepted by each of them
+var timestampLayouts = []string{
+	TimestampLayout,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// Timestamp is a point in time reported by the VOC API
+//
+// The zero value represents a missing (null or empty) timestamp. A decoded Timestamp marshals back to the exact
+// JSON value it was decoded from unless its Time was changed
+type Timestamp struct {
+	time.Time
+
+	raw     string    // the JSON value the Timestamp was decoded from
+	rawTime time.Time // the Time parsed from raw
+}
+
+// NewTimestamp returns a Timestamp of t formatted using TimestampLayout
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t}
+}
+
+// ParseTimestamp parses s in any of the formats used by the VOC API
+func ParseTimestamp(s string) (Timestamp, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return Timestamp{Time: t, raw: strconv.Quote(s), rawTime: t}, nil
+		}
+	}
+	return Timestamp{}, fmt.Errorf("invalid timestamp %q: expected a format like %s", s, TimestampLayout)
+}
+
+// String returns the timestamp formatted using TimestampLayout, or an empty string if it is zero
+func (t Timestamp) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(TimestampLayout)
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.raw != "" && t.Time.Equal(t.rawTime) {
+		return []byte(t.raw), nil
+	}
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(strconv.Quote(t.Format(TimestampLayout))), nil
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	raw := string(data)
+	if raw == "null" {
+		*t = Timestamp{raw: raw}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid timestamp %s: %w", raw, err)
+	}
+	if s == "" {
+		*t = Timestamp{raw: raw}
+		return nil
+	}
+	parsed, err := ParseTimestamp(s)
+	if err != nil {
+		return err
+	}
+	parsed.raw = raw
+	*t = parsed
+	return nil
+}
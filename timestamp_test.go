@@ -0,0 +1,78 @@
+package vocdriver_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+)
+
+func TestTimestamp_UnmarshalJSON(t *testing.T) {
+	expected := time.Date(2022, 11, 20, 8, 15, 0, 0, time.UTC)
+	for _, input := range []string{
+		`"2022-11-20T08:15:00+0000"`,
+		`"2022-11-20T09:15:00+0100"`,
+		`"2022-11-20T08:15:00.000+0000"`,
+		`"2022-11-20T08:15:00Z"`,
+		`"2022-11-20T10:15:00+02:00"`,
+	} {
+		var ts vocdriver.Timestamp
+		if err := json.Unmarshal([]byte(input), &ts); err != nil {
+			t.Errorf("%s: %v", input, err)
+			continue
+		}
+		if !ts.Equal(expected) {
+			t.Errorf("%s: got %s", input, ts)
+		}
+		// decoded timestamps marshal back losslessly
+		if out, _ := json.Marshal(ts); string(out) != input {
+			t.Errorf("%s: marshalled to %s", input, out)
+		}
+	}
+
+	for _, input := range []string{`null`, `""`} {
+		var ts vocdriver.Timestamp
+		if err := json.Unmarshal([]byte(input), &ts); err != nil || !ts.IsZero() {
+			t.Errorf("%s: expected a zero timestamp, got %s, %v", input, ts, err)
+		}
+		if out, _ := json.Marshal(ts); string(out) != input {
+			t.Errorf("%s: marshalled to %s", input, out)
+		}
+	}
+
+	var ts vocdriver.Timestamp
+	if err := json.Unmarshal([]byte(`"yesterday"`), &ts); err == nil {
+		t.Error("expected an error for an invalid timestamp")
+	}
+}
+
+func TestTimestamp_MarshalJSON(t *testing.T) {
+	ts := vocdriver.NewTimestamp(time.Date(2022, 11, 20, 8, 15, 0, 0, time.UTC))
+	if out, _ := json.Marshal(ts); string(out) != `"2022-11-20T08:15:00+0000"` {
+		t.Errorf("unexpected JSON: %s", out)
+	}
+	if out, _ := json.Marshal(vocdriver.Timestamp{}); string(out) != `null` {
+		t.Errorf("unexpected JSON of a zero timestamp: %s", out)
+	}
+
+	// changing a decoded timestamp discards its original representation
+	if err := json.Unmarshal([]byte(`"2022-11-20T08:15:00.000Z"`), &ts); err != nil {
+		t.Fatal(err)
+	}
+	ts.Time = ts.Add(time.Hour)
+	if out, _ := json.Marshal(ts); string(out) != `"2022-11-20T09:15:00+0000"` {
+		t.Errorf("unexpected JSON of a changed timestamp: %s", out)
+	}
+}
+
+func TestVehicleServiceStatus_Timestamps(t *testing.T) {
+	var vss vocdriver.VehicleServiceStatus
+	err := json.Unmarshal([]byte(`{"status":"Successful","statusTimestamp":"2022-11-20T08:15:30+0000","startTime":"2022-11-20T08:15:00+0000"}`), &vss)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := vss.StatusTimestamp.Sub(vss.StartTime.Time); d != 30*time.Second {
+		t.Errorf("unexpected duration: %s", d)
+	}
+}
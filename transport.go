@@ -0,0 +1,206 @@
+package vocdriver
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// redactedValue replaces secret header values in recordings
+const redactedValue = "REDACTED"
+
+// vinPattern matches strings shaped like a VIN (17 characters, the letters I, O and Q are never used)
+var vinPattern = regexp.MustCompile(`\b[A-HJ-NPR-Z0-9]{17}\b`)
+
+// pseudonymPattern matches the placeholders VINs are replaced with in recordings
+var pseudonymPattern = regexp.MustCompile(`\bREDACTEDVIN\d{6}\b`)
+
+// secretHeaders are never written to recordings in clear text
+var secretHeaders = [...]string{"Authorization", "Cookie", "Set-Cookie"}
+
+// Interaction is a single request/response pair recorded as one line of a JSONL file
+type Interaction struct {
+	Method         string      `json:"method"`
+	URL            string      `json:"url"`
+	RequestHeader  http.Header `json:"requestHeader,omitempty"`
+	RequestBody    string      `json:"requestBody,omitempty"`
+	StatusCode     int         `json:"statusCode"`
+	ResponseHeader http.Header `json:"responseHeader,omitempty"`
+	ResponseBody   string      `json:"responseBody,omitempty"`
+}
+
+// RecordingTransport is an http.RoundTripper writing every request/response passing through it to a JSONL stream
+//
+// The Authorization header is redacted and every VIN is replaced with a stable placeholder (e.g.: REDACTEDVIN000001),
+// so recordings can be attached to bug reports and replayed with ReplayTransport
+type RecordingTransport struct {
+	Transport http.RoundTripper // the underlying transport. http.DefaultTransport is used if nil
+
+	mu   sync.Mutex
+	w    io.Writer
+	vins map[string]string // VIN -> placeholder
+}
+
+// NewRecordingTransport returns a RecordingTransport writing to w and sending requests via transport
+//
+// Typical usage: client.HTTPClient.Transport = vocdriver.NewRecordingTransport(file, client.HTTPClient.Transport)
+func NewRecordingTransport(w io.Writer, transport http.RoundTripper) *RecordingTransport {
+	return &RecordingTransport{
+		Transport: transport,
+		w:         w,
+		vins:      map[string]string{},
+	}
+}
+
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil {
+		var err error
+		if requestBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	interaction := Interaction{
+		Method:         req.Method,
+		URL:            t.redact(req.URL.String()),
+		RequestHeader:  t.redactHeader(req.Header),
+		RequestBody:    t.redact(string(requestBody)),
+		StatusCode:     resp.StatusCode,
+		ResponseHeader: t.redactHeader(resp.Header),
+		ResponseBody:   t.redact(string(responseBody)),
+	}
+	line, err := json.Marshal(interaction)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = t.w.Write(append(line, '\n')); err != nil {
+		return nil, fmt.Errorf("failed to record %s %s: %w", req.Method, interaction.URL, err)
+	}
+	return resp, nil
+}
+
+// redact replaces every VIN in s with its placeholder
+func (t *RecordingTransport) redact(s string) string {
+	return vinPattern.ReplaceAllStringFunc(s, func(vin string) string {
+		if !strings.ContainsAny(vin, "ABCDEFGHJKLMNPRSTUVWXYZ") || !strings.ContainsAny(vin, "0123456789") {
+			return vin // e.g.: a long number
+		}
+		placeholder, ok := t.vins[vin]
+		if !ok {
+			placeholder = fmt.Sprintf("REDACTEDVIN%06d", len(t.vins)+1)
+			t.vins[vin] = placeholder
+		}
+		return placeholder
+	})
+}
+
+func (t *RecordingTransport) redactHeader(header http.Header) http.Header {
+	redacted := redactSecretHeaders(header)
+	for _, values := range redacted {
+		for i, value := range values {
+			values[i] = t.redact(value)
+		}
+	}
+	return redacted
+}
+
+// ReplayTransport is an http.RoundTripper answering requests from interactions recorded by RecordingTransport
+//
+// Requests are matched by method, path and query; the scheme, host and VINs are ignored. Identical requests
+// (e.g.: polling a service status) are answered with the recorded responses in their original order
+type ReplayTransport struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayTransport reads the JSONL stream produced by RecordingTransport from r
+func NewReplayTransport(r io.Reader) (*ReplayTransport, error) {
+	t := &ReplayTransport{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var interaction Interaction
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return nil, fmt.Errorf("invalid interaction at line %d: %w", line, err)
+		}
+		t.interactions = append(t.interactions, interaction)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	t.used = make([]bool, len(t.interactions))
+	return t, nil
+}
+
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	key := replayKey(req.Method, req.URL.RequestURI())
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, interaction := range t.interactions {
+		if t.used[i] {
+			continue
+		}
+		recorded, err := http.NewRequest(interaction.Method, interaction.URL, nil)
+		if err != nil || replayKey(recorded.Method, recorded.URL.RequestURI()) != key {
+			continue
+		}
+		t.used[i] = true
+		header := interaction.ResponseHeader
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.StatusCode, http.StatusText(interaction.StatusCode)),
+			StatusCode:    interaction.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(interaction.ResponseBody)),
+			ContentLength: int64(len(interaction.ResponseBody)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("replay: no recorded response left for %s %s", req.Method, req.URL.RequestURI())
+}
+
+// replayKey identifies a request independently of the VINs it contains
+func replayKey(method, requestURI string) string {
+	requestURI = pseudonymPattern.ReplaceAllString(requestURI, "{vin}")
+	requestURI = vinPattern.ReplaceAllString(requestURI, "{vin}")
+	return method + " " + requestURI
+}
@@ -0,0 +1,74 @@
+package vocdriver_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+	"github.com/theriverman/VolvoOnCall/voctest"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	srv := voctest.NewServer(nil)
+	defer srv.Close()
+	ctx := context.Background()
+
+	// record
+	var recording bytes.Buffer
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.HTTPClient.Transport = vocdriver.NewRecordingTransport(&recording, client.HTTPClient.Transport)
+	recorded, err := client.Vehicles.GetVehicleByVIN(ctx, voctest.VIN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vss, err := client.Vehicles.LockVehicle(ctx, voctest.VIN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = vss.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	s := recording.String()
+	if strings.Contains(s, voctest.VIN) || !strings.Contains(s, "REDACTEDVIN000001") {
+		t.Error("expected the VIN to be redacted from the recording")
+	}
+	if strings.Contains(s, "Basic ") || !strings.Contains(s, `"Authorization":["REDACTED"]`) {
+		t.Error("expected the Authorization header to be redacted from the recording")
+	}
+	if n := strings.Count(s, "\n"); n != 6 { // vehicle, attributes, status, relation, lock, service
+		t.Errorf("expected 6 interactions, got %d", n)
+	}
+
+	// replay against an unreachable API using the VIN pseudonym
+	replay, err := vocdriver.NewReplayTransport(&recording)
+	if err != nil {
+		t.Fatal(err)
+	}
+	offline := &vocdriver.Client{BaseURL: "http://voc.invalid"}
+	if err = offline.Initialise(); err != nil {
+		t.Fatal(err)
+	}
+	offline.HTTPClient.Transport = replay
+	replayed, err := offline.Vehicles.GetVehicleByVIN(ctx, "REDACTEDVIN000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayed.Attributes.RegistrationNumber != recorded.Attributes.RegistrationNumber || replayed.Status.Odometer != recorded.Status.Odometer {
+		t.Errorf("replayed vehicle differs from the recorded one: %+v", replayed.Attributes)
+	}
+	if vss, err = offline.Vehicles.LockVehicle(ctx, voctest.VIN); err != nil {
+		t.Fatal(err)
+	}
+	if err = vss.Refresh(ctx); err != nil || vss.Status != "Successful" {
+		t.Errorf("unexpected replayed service status %s: %v", vss.Status, err)
+	}
+	if _, err = offline.Vehicles.LockVehicle(ctx, voctest.VIN); err == nil {
+		t.Error("expected an error once the recorded interactions are exhausted")
+	}
+}
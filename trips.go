@@ -0,0 +1,215 @@
+package vocdriver
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TripCategory classifies a trip in the journal log, e.g.: for a tax logbook
+type TripCategory string
+
+const (
+	TripCategoryBusiness TripCategory = "business"
+	TripCategoryPrivate  TripCategory = "private"
+	TripCategoryCommute  TripCategory = "commute"
+)
+
+// TripCategories are the categories a trip can be classified as
+var TripCategories = []TripCategory{TripCategoryBusiness, TripCategoryPrivate, TripCategoryCommute}
+
+// ParseTripCategory parses the name of a TripCategory, ignoring its case (e.g.: Business)
+func ParseTripCategory(s string) (TripCategory, error) {
+	c := TripCategory(strings.ToLower(strings.TrimSpace(s)))
+	if !c.IsValid() {
+		return "", fmt.Errorf("unknown trip category %q: expected one of %v", s, TripCategories)
+	}
+	return c, nil
+}
+
+// IsValid returns true if c is one of TripCategories
+func (c TripCategory) IsValid() bool {
+	for _, category := range TripCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// TripUpdate holds the journal log fields of a trip to change. Nil fields keep their current value
+type TripUpdate struct {
+	Name      *string       `json:"name,omitempty"`
+	Category  *TripCategory `json:"category,omitempty"`
+	UserNotes *string       `json:"userNotes,omitempty"`
+}
+
+// validate returns an error if the update changes nothing or the category is unknown
+func (u TripUpdate) validate() error {
+	if u.Name == nil && u.Category == nil && u.UserNotes == nil {
+		return fmt.Errorf("trip update must change the name, the category or the notes")
+	}
+	if u.Category != nil && !u.Category.IsValid() {
+		return fmt.Errorf("unknown trip category %q: expected one of %v", *u.Category, TripCategories)
+	}
+	return nil
+}
+
+// TripsQuery narrows down the trips retrieved by QueryVehicleTripsByVIN. Zero fields are not filtered on
+type TripsQuery struct {
+	Since    time.Time    // trips started at or after Since
+	Until    time.Time    // trips started before Until
+	Category TripCategory // e.g.: TripCategoryBusiness
+	Limit    int          // maximum number of trips returned, applied by the API
+	Offset   int          // number of matching trips skipped by the API, used for paging together with Limit
+}
+
+// validate returns an error if the paging options are negative or the date range is empty
+func (q TripsQuery) validate() error {
+	if q.Limit < 0 || q.Offset < 0 {
+		return fmt.Errorf("limit and offset must not be negative, got %d and %d", q.Limit, q.Offset)
+	}
+	if !q.Since.IsZero() && !q.Until.IsZero() && !q.Since.Before(q.Until) {
+		return fmt.Errorf("since (%s) must be before until (%s)", q.Since.Format(TimestampLayout), q.Until.Format(TimestampLayout))
+	}
+	return nil
+}
+
+// Values returns the query parameters sent to the VOC API
+func (q TripsQuery) Values() url.Values {
+	values := url.Values{}
+	if !q.Since.IsZero() {
+		values.Set("since", q.Since.Format(TimestampLayout))
+	}
+	if !q.Until.IsZero() {
+		values.Set("until", q.Until.Format(TimestampLayout))
+	}
+	if q.Category != "" {
+		values.Set("category", string(q.Category))
+	}
+	if q.Limit > 0 {
+		values.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if q.Offset > 0 {
+		values.Set("offset", strconv.Itoa(q.Offset))
+	}
+	return values
+}
+
+// Matches returns true if trip started within the date range and has the category of the query
+func (q TripsQuery) Matches(trip Trip) bool {
+	if q.Category != "" && trip.Category != q.Category {
+		return false
+	}
+	start := trip.StartTime()
+	if !q.Since.IsZero() && (start.IsZero() || start.Before(q.Since)) {
+		return false
+	}
+	if !q.Until.IsZero() && (start.IsZero() || !start.Before(q.Until)) {
+		return false
+	}
+	return true
+}
+
+// StartTime returns the start time of the first detail of the trip, or a zero Timestamp if it has none
+func (t Trip) StartTime() (start Timestamp) {
+	for _, td := range t.TripDetails {
+		if !td.StartTime.IsZero() && (start.IsZero() || td.StartTime.Before(start.Time)) {
+			start = td.StartTime
+		}
+	}
+	return
+}
+
+// QueryVehicleTripsByVIN retrieves the trips of the car matching query. If query is nil, every trip is retrieved
+//
+// The date range and the category are applied again to the response, so the result is narrowed down even if the API
+// ignores them. Paging (Limit and Offset) is left to the API: applying a page twice would skip matching trips
+func (v *VehiclesService) QueryVehicleTripsByVIN(ctx context.Context, vin string, query *TripsQuery) (trips *VehicleTrips, err error) {
+	if vin == "" {
+		return nil, fmt.Errorf("vin must not be empty")
+	}
+	var values url.Values
+	if query != nil {
+		if err = query.validate(); err != nil {
+			return nil, err
+		}
+		values = query.Values()
+	}
+	url := v.client.MakeURLWithQuery(values, v.Endpoint, vin, "trips")
+	if _, err = v.client.Request.Get(ctx, url, &trips); err != nil {
+		return nil, err
+	}
+	trips.client = v.client
+	if query != nil {
+		trips.filter(*query)
+	}
+	return
+}
+
+// filter drops the trips not matching the date range and the category of query
+func (vt *VehicleTrips) filter(query TripsQuery) {
+	matching := vt.Trips[:0]
+	for _, trip := range vt.Trips {
+		if query.Matches(trip) {
+			matching = append(matching, trip)
+		}
+	}
+	vt.Trips = matching
+}
+
+// UpdateTrip changes the name, the category or the notes of a trip in the journal log and returns the updated trip
+func (v *VehiclesService) UpdateTrip(ctx context.Context, vin string, tripId int, update *TripUpdate) (trip *Trip, err error) {
+	if vin == "" {
+		return nil, fmt.Errorf("vin must not be empty")
+	}
+	if tripId <= 0 {
+		return nil, fmt.Errorf("tripId must be positive, got %d", tripId)
+	}
+	if update == nil {
+		return nil, fmt.Errorf("trip update must not be empty")
+	}
+	if err = update.validate(); err != nil {
+		return nil, err
+	}
+	url := v.client.MakeURL(v.Endpoint, vin, "trips", strconv.Itoa(tripId))
+	if _, err = v.client.Request.Put(ctx, url, update, &trip); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// DeleteTrip removes a trip from the journal log
+func (v *VehiclesService) DeleteTrip(ctx context.Context, vin string, tripId int) (err error) {
+	if vin == "" {
+		return fmt.Errorf("vin must not be empty")
+	}
+	if tripId <= 0 {
+		return fmt.Errorf("tripId must be positive, got %d", tripId)
+	}
+	url := v.client.MakeURL(v.Endpoint, vin, "trips", strconv.Itoa(tripId))
+	_, err = v.client.Request.Delete(ctx, url)
+	return
+}
+
+func (v Vehicle) UpdateTrip(ctx context.Context, tripId int, update *TripUpdate) (*Trip, error) {
+	if !v.IsJournalLogSupported() {
+		return nil, fmt.Errorf("the journal log is not supported by %s [%s]: %w", v.Attributes.RegistrationNumber, v.Attributes.Vin, ErrVehicleUnsupported)
+	}
+	return v.client.Vehicles.UpdateTrip(ctx, v.VehicleID, tripId, update)
+}
+
+// SetTripCategory classifies a trip, keeping its name and notes
+func (v Vehicle) SetTripCategory(ctx context.Context, tripId int, category TripCategory) (*Trip, error) {
+	return v.UpdateTrip(ctx, tripId, &TripUpdate{Category: &category})
+}
+
+func (v Vehicle) DeleteTrip(ctx context.Context, tripId int) error {
+	if !v.IsJournalLogSupported() {
+		return fmt.Errorf("the journal log is not supported by %s [%s]: %w", v.Attributes.RegistrationNumber, v.Attributes.Vin, ErrVehicleUnsupported)
+	}
+	return v.client.Vehicles.DeleteTrip(ctx, v.VehicleID, tripId)
+}
@@ -0,0 +1,196 @@
+package vocdriver_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+	"github.com/theriverman/VolvoOnCall/voctest"
+)
+
+// tripsFixture returns a trip of category starting at start
+func tripsFixture(id int, category vocdriver.TripCategory, start time.Time) vocdriver.Trip {
+	return vocdriver.Trip{
+		ID:       id,
+		Category: category,
+		TripDetails: []vocdriver.TripDetail{{
+			StartTime: vocdriver.NewTimestamp(start),
+			EndTime:   vocdriver.NewTimestamp(start.Add(30 * time.Minute)),
+		}},
+	}
+}
+
+func TestVehicle_QueryTrips(t *testing.T) {
+	fixtures := voctest.DefaultFixtures()
+	fixtures.Vehicles[0].Trips = append(fixtures.Vehicles[0].Trips, // 1001 is a private trip on 2022-11-19
+		tripsFixture(1002, "business", time.Date(2022, 10, 5, 8, 0, 0, 0, time.UTC)),
+		tripsFixture(1003, "business", time.Date(2022, 11, 25, 8, 0, 0, 0, time.UTC)),
+		tripsFixture(1004, "business", time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)),
+	)
+	srv, _, vehicle := newTestVehicle(t, fixtures)
+	ctx := context.Background()
+
+	november := vocdriver.TripsQuery{
+		Since:    time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC),
+		Until:    time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC),
+		Category: "business",
+	}
+	trips, err := vehicle.QueryTrips(ctx, &november)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trips.Trips) != 1 || trips.Trips[0].ID != 1003 {
+		t.Errorf("unexpected trips: %+v", trips.Trips)
+	}
+	requests := srv.Handler.Requests()
+	if q := requests[len(requests)-1].Query; q.Get("since") != "2022-11-01T00:00:00+0000" || q.Get("until") != "2022-12-01T00:00:00+0000" || q.Get("category") != "business" || q.Has("limit") {
+		t.Errorf("unexpected query: %v", q)
+	}
+
+	trips, err = vehicle.QueryTrips(ctx, &vocdriver.TripsQuery{Limit: 2, Offset: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trips.Trips) != 2 || trips.Trips[0].ID != 1002 || trips.Trips[1].ID != 1003 {
+		t.Errorf("unexpected page: %+v", trips.Trips)
+	}
+
+	for _, invalid := range []vocdriver.TripsQuery{{Limit: -1}, {Since: november.Until, Until: november.Since}} {
+		if _, err = vehicle.QueryTrips(ctx, &invalid); err == nil {
+			t.Errorf("expected %+v to be rejected", invalid)
+		}
+	}
+}
+
+func TestParseTripCategory(t *testing.T) {
+	for s, expected := range map[string]vocdriver.TripCategory{
+		"business":  vocdriver.TripCategoryBusiness,
+		" Private ": vocdriver.TripCategoryPrivate,
+		"COMMUTE":   vocdriver.TripCategoryCommute,
+	} {
+		c, err := vocdriver.ParseTripCategory(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c != expected {
+			t.Errorf("%q: expected %s, got %s", s, expected, c)
+		}
+	}
+	for _, invalid := range []string{"", "leisure", "business,private"} {
+		if _, err := vocdriver.ParseTripCategory(invalid); err == nil {
+			t.Errorf("expected %q to be rejected", invalid)
+		}
+	}
+}
+
+func TestVehicle_UpdateTrip(t *testing.T) {
+	srv, _, vehicle := newTestVehicle(t, nil)
+	ctx := context.Background()
+
+	notes := "client visit"
+	category := vocdriver.TripCategoryBusiness
+	trip, err := vehicle.UpdateTrip(ctx, 1001, &vocdriver.TripUpdate{Category: &category, UserNotes: &notes})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trip.Category != vocdriver.TripCategoryBusiness || trip.UserNotes != notes {
+		t.Errorf("unexpected trip: %+v", trip)
+	}
+	requests := srv.Handler.Requests()
+	if body := string(requests[len(requests)-1].Body); body != `{"category":"business","userNotes":"client visit"}` {
+		t.Errorf("only the changed fields must be sent, got %s", body)
+	}
+
+	name := "Kista - Stockholm"
+	if _, err = vehicle.UpdateTrip(ctx, 1001, &vocdriver.TripUpdate{Name: &name}); err != nil {
+		t.Fatal(err)
+	}
+	if trip, err = vehicle.SetTripCategory(ctx, 1001, vocdriver.TripCategoryCommute); err != nil {
+		t.Fatal(err)
+	}
+	if trip.Name != name || trip.Category != vocdriver.TripCategoryCommute || trip.UserNotes != notes {
+		t.Errorf("unset fields should keep their value, got %+v", trip)
+	}
+
+	unknown := vocdriver.TripCategory("leisure")
+	for _, invalid := range []vocdriver.TripUpdate{{}, {Category: &unknown}} {
+		if _, err = vehicle.UpdateTrip(ctx, 1001, &invalid); err == nil {
+			t.Errorf("expected %+v to be rejected", invalid)
+		}
+	}
+	if _, err = vehicle.UpdateTrip(ctx, 1001, nil); err == nil {
+		t.Error("expected a nil update to be rejected")
+	}
+	if _, err = vehicle.UpdateTrip(ctx, 42, &vocdriver.TripUpdate{Name: &name}); !vocdriver.IsNotFound(err) {
+		t.Errorf("expected an unknown trip to be not found, got %v", err)
+	}
+}
+
+func TestVehicle_DeleteTrip(t *testing.T) {
+	_, _, vehicle := newTestVehicle(t, nil)
+	ctx := context.Background()
+	if err := vehicle.DeleteTrip(ctx, 1001); err != nil {
+		t.Fatal(err)
+	}
+	trips, err := vehicle.GetTrips(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trips.Trips) != 0 {
+		t.Errorf("unexpected trips: %+v", trips.Trips)
+	}
+
+	vehicle.Attributes.JournalLogSupported = false
+	if err = vehicle.DeleteTrip(ctx, 1001); !errors.Is(err, vocdriver.ErrVehicleUnsupported) {
+		t.Errorf("expected ErrVehicleUnsupported, got %v", err)
+	}
+}
+
+func TestQueryVehicleTripsByVIN_IgnoredParameters(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"trips": []vocdriver.Trip{ // ignores every parameter
+			tripsFixture(1, "private", time.Date(2022, 11, 2, 8, 0, 0, 0, time.UTC)),
+			tripsFixture(2, "business", time.Date(2022, 11, 3, 8, 0, 0, 0, time.UTC)),
+			tripsFixture(3, "business", time.Date(2022, 11, 4, 8, 0, 0, 0, time.UTC)),
+			tripsFixture(4, "business", time.Date(2022, 12, 4, 8, 0, 0, 0, time.UTC)),
+		}})
+	}))
+	defer srv.Close()
+	client, err := vocdriver.NewClient(vocdriver.WithBaseURL(srv.URL), vocdriver.WithCredentials("john.doe@example.com", "secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trips, err := client.Vehicles.QueryVehicleTripsByVIN(context.Background(), voctest.VIN, &vocdriver.TripsQuery{
+		Until:    time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC),
+		Category: "business",
+		Limit:    1,
+		Offset:   1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the filters are applied to the response, the page is left to the API
+	if len(trips.Trips) != 2 || trips.Trips[0].ID != 2 || trips.Trips[1].ID != 3 {
+		t.Errorf("expected the filters to be applied to the response, got %+v", trips.Trips)
+	}
+}
+
+func TestClient_MakeURLWithQuery(t *testing.T) {
+	client, err := vocdriver.NewClient(vocdriver.WithBaseURL("http://127.0.0.1:8080"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := vocdriver.TripsQuery{Category: "commute & errands", Limit: 10}.Values()
+	if got := client.MakeURLWithQuery(query, "vehicles", voctest.VIN, "trips"); got != "http://127.0.0.1:8080/vehicles/"+voctest.VIN+"/trips?category=commute+%26+errands&limit=10" {
+		t.Errorf("unexpected URL: %s", got)
+	}
+	if got := client.MakeURLWithQuery(nil, "vehicles"); got != "http://127.0.0.1:8080/vehicles" {
+		t.Errorf("unexpected URL without query: %s", got)
+	}
+}
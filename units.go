@@ -0,0 +1,200 @@
+package vocdriver
+
+import "fmt"
+
+// Distance is a length stored in meters. Multiply a number by one of the unit constants to create one, e.g.: 12 * Kilometer
+type Distance float64
+
+const (
+	Meter            Distance = 1
+	Kilometer        Distance = 1000
+	Mile             Distance = 1609.344
+	ScandinavianMile Distance = 10000 // mil, commonly used in Sweden and Norway
+)
+
+func (d Distance) Meters() float64            { return float64(d) }
+func (d Distance) Kilometers() float64        { return float64(d / Kilometer) }
+func (d Distance) Miles() float64             { return float64(d / Mile) }
+func (d Distance) ScandinavianMiles() float64 { return float64(d / ScandinavianMile) }
+
+func (d Distance) String() string { return fmt.Sprintf("%.1f km", d.Kilometers()) }
+
+// Volume is a liquid volume stored in litres
+type Volume float64
+
+const (
+	Centiliter     Volume = 0.01
+	Liter          Volume = 1
+	USGallon       Volume = 3.785411784
+	ImperialGallon Volume = 4.54609
+)
+
+func (v Volume) Liters() float64          { return float64(v) }
+func (v Volume) USGallons() float64       { return float64(v / USGallon) }
+func (v Volume) ImperialGallons() float64 { return float64(v / ImperialGallon) }
+
+func (v Volume) String() string { return fmt.Sprintf("%.2f l", v.Liters()) }
+
+// Energy is stored in watt-hours
+type Energy float64
+
+const (
+	WattHour     Energy = 1
+	KilowattHour Energy = 1000
+)
+
+func (e Energy) WattHours() float64     { return float64(e) }
+func (e Energy) KilowattHours() float64 { return float64(e / KilowattHour) }
+
+func (e Energy) String() string { return fmt.Sprintf("%.3f kWh", e.KilowattHours()) }
+
+// Speed is stored in kilometres per hour
+type Speed float64
+
+const (
+	KilometersPerHour Speed = 1
+	MilesPerHour      Speed = 1.609344
+	MetersPerSecond   Speed = 3.6
+)
+
+func (s Speed) KilometersPerHour() float64 { return float64(s) }
+func (s Speed) MilesPerHour() float64      { return float64(s / MilesPerHour) }
+func (s Speed) MetersPerSecond() float64   { return float64(s / MetersPerSecond) }
+
+func (s Speed) String() string { return fmt.Sprintf("%.0f km/h", s.KilometersPerHour()) }
+
+// FuelEfficiency is a fuel consumption stored in litres per 100 kilometres
+type FuelEfficiency float64
+
+// LitersPer100Kilometers is the unit of FuelEfficiency, e.g.: 6.5 * LitersPer100Kilometers
+const LitersPer100Kilometers FuelEfficiency = 1
+
+// NewFuelEfficiency returns the consumption of burning volume over distance, or 0 if distance is not positive
+func NewFuelEfficiency(volume Volume, distance Distance) FuelEfficiency {
+	if distance <= 0 {
+		return 0
+	}
+	return FuelEfficiency(volume.Liters() / (distance.Kilometers() / 100))
+}
+
+func (f FuelEfficiency) LitersPer100Kilometers() float64 { return float64(f) }
+
+// KilometersPerLiter returns 0 if no fuel was consumed
+func (f FuelEfficiency) KilometersPerLiter() float64 {
+	if f <= 0 {
+		return 0
+	}
+	return 100 / float64(f)
+}
+
+// MilesPerUSGallon returns 0 if no fuel was consumed
+func (f FuelEfficiency) MilesPerUSGallon() float64 {
+	if f <= 0 {
+		return 0
+	}
+	return float64(100*Kilometer/Mile) * float64(USGallon) / float64(f)
+}
+
+// MilesPerImperialGallon returns 0 if no fuel was consumed
+func (f FuelEfficiency) MilesPerImperialGallon() float64 {
+	if f <= 0 {
+		return 0
+	}
+	return float64(100*Kilometer/Mile) * float64(ImperialGallon) / float64(f)
+}
+
+func (f FuelEfficiency) String() string {
+	return fmt.Sprintf("%.1f l/100 km", f.LitersPer100Kilometers())
+}
+
+// StatusReadings are the physical values of a VehicleStatus converted from the units used by the VOC API
+type StatusReadings struct {
+	AverageFuelConsumption   FuelEfficiency
+	AverageSpeed             Speed
+	DistanceToEmpty          Distance
+	DistanceToHVBatteryEmpty Distance
+	FuelAmount               Volume
+	Odometer                 Distance
+	TripMeter1               Distance
+	TripMeter2               Distance
+}
+
+// Readings converts the raw values of the status:
+//
+//   - averageFuelConsumption is reported in dl/100 km
+//   - distanceToEmpty and distanceToHVBatteryEmpty in km
+//   - odometer and the trip meters in m
+//   - fuelAmount in l and averageSpeed in km/h
+func (vs VehicleStatus) Readings() StatusReadings {
+	return StatusReadings{
+		AverageFuelConsumption:   FuelEfficiency(vs.AverageFuelConsumption / 10),
+		AverageSpeed:             Speed(vs.AverageSpeed) * KilometersPerHour,
+		DistanceToEmpty:          Distance(vs.DistanceToEmpty) * Kilometer,
+		DistanceToHVBatteryEmpty: Distance(vs.HvBattery.DistanceToHVBatteryEmpty) * Kilometer,
+		FuelAmount:               Volume(vs.FuelAmount) * Liter,
+		Odometer:                 Distance(vs.Odometer) * Meter,
+		TripMeter1:               Distance(vs.TripMeter1) * Meter,
+		TripMeter2:               Distance(vs.TripMeter2) * Meter,
+	}
+}
+
+// TripReadings are the physical values of a TripDetail (or of every detail of a Trip) converted from the units used by the VOC API
+type TripReadings struct {
+	FuelConsumption        Volume
+	ElectricalConsumption  Energy
+	ElectricalRegeneration Energy
+	Distance               Distance
+	StartOdometer          Distance
+	EndOdometer            Distance
+}
+
+// FuelEfficiency returns the average fuel consumption of the trip
+func (tr TripReadings) FuelEfficiency() FuelEfficiency {
+	return NewFuelEfficiency(tr.FuelConsumption, tr.Distance)
+}
+
+// Readings converts the raw values of the trip detail: fuel is reported in cl, electrical energy in Wh, distances in m
+func (td TripDetail) Readings() TripReadings {
+	return TripReadings{
+		FuelConsumption:        Volume(td.FuelConsumption) * Centiliter,
+		ElectricalConsumption:  Energy(td.ElectricalConsumption) * WattHour,
+		ElectricalRegeneration: Energy(td.ElectricalRegeneration) * WattHour,
+		Distance:               Distance(td.Distance) * Meter,
+		StartOdometer:          Distance(td.StartOdometer) * Meter,
+		EndOdometer:            Distance(td.EndOdometer) * Meter,
+	}
+}
+
+// Readings sums the readings of every detail of the trip. The odometer values span from the first to the last detail
+func (t Trip) Readings() (total TripReadings) {
+	for i, td := range t.TripDetails {
+		r := td.Readings()
+		total.FuelConsumption += r.FuelConsumption
+		total.ElectricalConsumption += r.ElectricalConsumption
+		total.ElectricalRegeneration += r.ElectricalRegeneration
+		total.Distance += r.Distance
+		if i == 0 {
+			total.StartOdometer = r.StartOdometer
+		}
+		total.EndOdometer = r.EndOdometer
+	}
+	return total
+}
+
+// Readings sums the readings of every trip
+func (vt VehicleTrips) Readings() (total TripReadings) {
+	for i, t := range vt.Trips {
+		r := t.Readings()
+		total.FuelConsumption += r.FuelConsumption
+		total.ElectricalConsumption += r.ElectricalConsumption
+		total.ElectricalRegeneration += r.ElectricalRegeneration
+		total.Distance += r.Distance
+		if i == 0 || r.StartOdometer < total.StartOdometer {
+			total.StartOdometer = r.StartOdometer
+		}
+		if r.EndOdometer > total.EndOdometer {
+			total.EndOdometer = r.EndOdometer
+		}
+	}
+	return total
+}
@@ -0,0 +1,49 @@
+package vocdriver_test
+
+import (
+	"math"
+	"testing"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+	"github.com/theriverman/VolvoOnCall/voctest"
+)
+
+func assertAbout(t *testing.T, name string, got, expected float64) {
+	t.Helper()
+	if math.Abs(got-expected) > 0.005 {
+		t.Errorf("%s: expected %.3f, got %.3f", name, expected, got)
+	}
+}
+
+func TestUnitConversions(t *testing.T) {
+	assertAbout(t, "km", (42195 * vocdriver.Meter).Kilometers(), 42.195)
+	assertAbout(t, "miles", (100 * vocdriver.Kilometer).Miles(), 62.137)
+	assertAbout(t, "mil", (25 * vocdriver.Kilometer).ScandinavianMiles(), 2.5)
+	assertAbout(t, "gallons", (50 * vocdriver.Liter).USGallons(), 13.209)
+	assertAbout(t, "imperial gallons", (50 * vocdriver.Liter).ImperialGallons(), 10.998)
+	assertAbout(t, "kWh", (4200 * vocdriver.WattHour).KilowattHours(), 4.2)
+	assertAbout(t, "mph", (100 * vocdriver.KilometersPerHour).MilesPerHour(), 62.137)
+	assertAbout(t, "mpg", (10 * vocdriver.LitersPer100Kilometers).MilesPerUSGallon(), 23.521)
+	assertAbout(t, "imperial mpg", (10 * vocdriver.LitersPer100Kilometers).MilesPerImperialGallon(), 28.248)
+	assertAbout(t, "km/l", (5 * vocdriver.LitersPer100Kilometers).KilometersPerLiter(), 20)
+	if mpg := vocdriver.FuelEfficiency(0).MilesPerUSGallon(); mpg != 0 {
+		t.Errorf("expected 0 mpg without consumption, got %f", mpg)
+	}
+}
+
+func TestReadings(t *testing.T) {
+	v := voctest.DefaultVehicle()
+
+	status := v.Status.Readings()
+	assertAbout(t, "average fuel consumption", status.AverageFuelConsumption.LitersPer100Kilometers(), 2.3)
+	assertAbout(t, "odometer", status.Odometer.Kilometers(), 42194.5)
+	assertAbout(t, "distance to empty", status.DistanceToEmpty.Kilometers(), 450)
+	assertAbout(t, "fuel amount", status.FuelAmount.Liters(), 40)
+
+	trip := v.Trips[0].Readings()
+	assertAbout(t, "trip fuel", trip.FuelConsumption.Liters(), 1.2)
+	assertAbout(t, "trip energy", trip.ElectricalConsumption.KilowattHours(), 4.2)
+	assertAbout(t, "trip distance", trip.Distance.Kilometers(), 24.5)
+	assertAbout(t, "trip efficiency", trip.FuelEfficiency().LitersPer100Kilometers(), 4.898)
+	assertAbout(t, "trip odometer", (trip.EndOdometer - trip.StartOdometer).Kilometers(), 24.5)
+}
@@ -1,9 +1,95 @@
 package vocdriver
 
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
 type Position struct {
-	Longitude float64     `json:"longitude"`
-	Latitude  float64     `json:"latitude"`
-	Timestamp string      `json:"timestamp"`
-	Speed     interface{} `json:"speed"`   // TODO: figure out the actual type
-	Heading   interface{} `json:"heading"` // TODO: figure out the actual type
+	Longitude float64   `json:"longitude"`
+	Latitude  float64   `json:"latitude"`
+	Timestamp Timestamp `json:"timestamp"`
+	Speed     *Speed    `json:"speed"`   // nil if not reported by the car (e.g.: when parked)
+	Heading   *Heading  `json:"heading"` // nil if not reported by the car (e.g.: when parked)
+}
+
+// UnmarshalJSON accepts speed and heading as a number, a numeric string or null
+func (p *Position) UnmarshalJSON(data []byte) error {
+	type position Position // avoids recursion
+	aux := struct {
+		*position
+		Speed   json.RawMessage `json:"speed"`
+		Heading json.RawMessage `json:"heading"`
+	}{position: (*position)(p)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	speed, ok, err := parseOptionalNumber(aux.Speed)
+	if err != nil {
+		return fmt.Errorf("invalid position speed: %w", err)
+	}
+	p.Speed = nil
+	if ok {
+		s := Speed(speed) * KilometersPerHour
+		p.Speed = &s
+	}
+
+	heading, ok, err := parseOptionalNumber(aux.Heading)
+	if err != nil {
+		return fmt.Errorf("invalid position heading: %w", err)
+	}
+	p.Heading = nil
+	if ok {
+		h := Heading(heading)
+		p.Heading = &h
+	}
+	return nil
+}
+
+// parseOptionalNumber parses a JSON number or numeric string. ok is false for missing, null or empty values
+func parseOptionalNumber(raw json.RawMessage) (f float64, ok bool, err error) {
+	s := strings.TrimSpace(string(raw))
+	if s == "" || s == "null" {
+		return 0, false, nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		if err = json.Unmarshal(raw, &s); err != nil {
+			return 0, false, err
+		}
+		if s = strings.TrimSpace(s); s == "" {
+			return 0, false, nil
+		}
+	}
+	if f, err = strconv.ParseFloat(s, 64); err != nil {
+		return 0, false, err
+	}
+	return f, true, nil
+}
+
+// Heading is a direction in degrees measured clockwise from north
+type Heading float64
+
+// compassPoints are the 16 points of the compass starting at north
+var compassPoints = [...]string{"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW"}
+
+// Degrees returns the heading normalised to [0, 360)
+func (h Heading) Degrees() float64 {
+	d := math.Mod(float64(h), 360)
+	if d < 0 {
+		d += 360
+	}
+	return d
+}
+
+// Compass returns the closest of the 16 compass points, e.g.: N, NNE, NE
+func (h Heading) Compass() string {
+	return compassPoints[int(math.Round(h.Degrees()/22.5))%len(compassPoints)]
+}
+
+func (h Heading) String() string {
+	return fmt.Sprintf("%.0f° %s", h.Degrees(), h.Compass())
 }
@@ -0,0 +1,61 @@
+package vocdriver_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+)
+
+func TestPosition_UnmarshalJSON(t *testing.T) {
+	for name, tc := range map[string]struct {
+		json             string
+		speed, heading   float64
+		hasSpeed, hasHdg bool
+	}{
+		"parked":          {json: `{"longitude":18.06,"latitude":59.32,"timestamp":"2022-11-20T08:15:00+0000","speed":null,"heading":null}`},
+		"omitted":         {json: `{"longitude":18.06,"latitude":59.32,"timestamp":"2022-11-20T08:15:00+0000"}`},
+		"empty strings":   {json: `{"longitude":18.06,"latitude":59.32,"speed":"","heading":""}`},
+		"numbers":         {json: `{"longitude":18.06,"latitude":59.32,"speed":87,"heading":271.5}`, speed: 87, heading: 271.5, hasSpeed: true, hasHdg: true},
+		"numeric strings": {json: `{"longitude":18.06,"latitude":59.32,"speed":"42.5","heading":"90"}`, speed: 42.5, heading: 90, hasSpeed: true, hasHdg: true},
+		"zero speed":      {json: `{"longitude":18.06,"latitude":59.32,"speed":0,"heading":null}`, hasSpeed: true},
+	} {
+		var p vocdriver.Position
+		if err := json.Unmarshal([]byte(tc.json), &p); err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if p.Longitude != 18.06 || p.Latitude != 59.32 {
+			t.Errorf("%s: unexpected coordinates %+v", name, p)
+		}
+		if (p.Speed != nil) != tc.hasSpeed || (p.Speed != nil && p.Speed.KilometersPerHour() != tc.speed) {
+			t.Errorf("%s: unexpected speed %v", name, p.Speed)
+		}
+		if (p.Heading != nil) != tc.hasHdg || (p.Heading != nil && p.Heading.Degrees() != tc.heading) {
+			t.Errorf("%s: unexpected heading %v", name, p.Heading)
+		}
+	}
+
+	var p vocdriver.Position
+	if err := json.Unmarshal([]byte(`{"speed":"fast"}`), &p); err == nil {
+		t.Error("expected an error for a non-numeric speed")
+	}
+}
+
+func TestHeading_Compass(t *testing.T) {
+	for heading, expected := range map[vocdriver.Heading]string{
+		0:     "N",
+		11:    "N",
+		12:    "NNE",
+		45:    "NE",
+		180:   "S",
+		271.5: "W",
+		350:   "N",
+		-90:   "W",
+		720:   "N",
+	} {
+		if got := heading.Compass(); got != expected {
+			t.Errorf("%.1f: expected %s, got %s", float64(heading), expected, got)
+		}
+	}
+}
@@ -1,17 +1,11 @@
 package vocdriver
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"time"
 )
 
-var ServiceTypeMap map[string]string = map[string]string{
-	"RDL":   "Lock Vehicle",
-	"RDU":   "Unlock Vehicle",
-	"RHBLF": "Blink Lights",
-}
-
 type VehiclesService struct {
 	client   *Client
 	Endpoint string
@@ -21,82 +15,75 @@ type VehiclesService struct {
 	Low-level Functions
 */
 
-func (v *VehiclesService) GetVehicleByVIN(vin string) (vehicle *Vehicle, err error) {
+func (v *VehiclesService) GetVehicleByVIN(ctx context.Context, vin string) (vehicle *Vehicle, err error) {
 	if vin == "" {
 		return nil, fmt.Errorf("vin must not be empty")
 	}
 	url := v.client.MakeURL(v.Endpoint, vin)
-	if _, err = v.client.Request.Get(url, &vehicle); err != nil {
+	if _, err = v.client.Request.Get(ctx, url, &vehicle); err != nil {
 		return nil, err
 	}
 	vehicle.client = v.client
-	err = vehicle.RetrieveHyperlinks()
+	err = vehicle.RetrieveHyperlinks(ctx)
 	return
 }
 
-func (v *VehiclesService) GetVehicleByHyperlink(url string) (vehicle *Vehicle, err error) {
+func (v *VehiclesService) GetVehicleByHyperlink(ctx context.Context, url string) (vehicle *Vehicle, err error) {
 	if url == "" {
 		return nil, fmt.Errorf("url must not be empty")
 	}
-	if _, err = v.client.Request.Get(url, &vehicle); err != nil {
+	if _, err = v.client.Request.Get(ctx, url, &vehicle); err != nil {
 		return nil, err
 	}
 	vehicle.client = v.client
-	err = vehicle.RetrieveHyperlinks()
+	err = vehicle.RetrieveHyperlinks(ctx)
 	return
 }
 
-func (v *VehiclesService) GetVehicleAttributesByVIN(vin string) (attributes *VehicleAttributes, err error) {
+func (v *VehiclesService) GetVehicleAttributesByVIN(ctx context.Context, vin string) (attributes *VehicleAttributes, err error) {
 	if vin == "" {
 		return nil, fmt.Errorf("vin must not be empty")
 	}
 	url := v.client.MakeURL(v.Endpoint, vin, "attributes")
-	if _, err = v.client.Request.Get(url, &attributes); err != nil {
+	if _, err = v.client.Request.Get(ctx, url, &attributes); err != nil {
 		return nil, err
 	}
 	attributes.client = v.client
+	v.client.rememberAttributes(vin, attributes)
 	return
 }
 
-func (v *VehiclesService) GetVehicleStatusByVIN(vin string) (status *VehicleStatus, err error) {
+func (v *VehiclesService) GetVehicleStatusByVIN(ctx context.Context, vin string) (status *VehicleStatus, err error) {
 	if vin == "" {
 		return nil, fmt.Errorf("vin must not be empty")
 	}
 	url := v.client.MakeURL(v.Endpoint, vin, "status")
-	if _, err = v.client.Request.Get(url, &status); err != nil {
+	if _, err = v.client.Request.Get(ctx, url, &status); err != nil {
 		return nil, err
 	}
 	status.client = v.client
 	return
 }
 
-func (v *VehiclesService) GetVehiclePositionByVIN(vin string) (position *VehiclePosition, err error) {
+func (v *VehiclesService) GetVehiclePositionByVIN(ctx context.Context, vin string) (position *VehiclePosition, err error) {
 	if vin == "" {
 		return nil, fmt.Errorf("vin must not be empty")
 	}
 	url := v.client.MakeURL(v.Endpoint, vin, "position")
-	if _, err = v.client.Request.Get(url, &position); err != nil {
+	if _, err = v.client.Request.Get(ctx, url, &position); err != nil {
 		return nil, err
 	}
 	position.client = v.client
 	return
 }
 
-func (v *VehiclesService) GetVehicleTripsByVIN(vin string) (trips *VehicleTrips, err error) {
-	if vin == "" {
-		return nil, fmt.Errorf("vin must not be empty")
-	}
-	url := v.client.MakeURL(v.Endpoint, vin, "trips")
-	if _, err = v.client.Request.Get(url, &trips); err != nil {
-		return nil, err
-	}
-	trips.client = v.client
-	return
+func (v *VehiclesService) GetVehicleTripsByVIN(ctx context.Context, vin string) (trips *VehicleTrips, err error) {
+	return v.QueryVehicleTripsByVIN(ctx, vin, nil)
 }
 
 // GetServiceStatus retrieves the current status of an async operation (typically an action sent to a vehicle)
-func (v *VehiclesService) GetServiceStatus(url string) (vss *VehicleServiceStatus, err error) {
-	if _, err = v.client.Request.Get(url, &vss); err != nil {
+func (v *VehiclesService) GetServiceStatus(ctx context.Context, url string) (vss *VehicleServiceStatus, err error) {
+	if _, err = v.client.Request.Get(ctx, url, &vss); err != nil {
 		return nil, err
 	}
 	vss.client = v.client
@@ -104,48 +91,78 @@ func (v *VehiclesService) GetServiceStatus(url string) (vss *VehicleServiceStatu
 }
 
 // EvaluateServiceStatusAuto hangs the main application waiting for the requested operation to finish
-// During this time the Service API is polled every second and the response is evaluated
-//   - if the request timeouts (default: 30s), an error is returned
+// The Service API is polled following the PollPolicy of the Client (default: DefaultPollPolicy) and the response is evaluated
+//   - if the request timeouts (default: 30s, or the vehicle's unlockTimeFrame for unlocks), an error is returned
 //   - if the request fails, an error is returned
-func (v *VehiclesService) EvaluateServiceStatusAuto(vss *VehicleServiceStatus) (err error) {
-	timeoutSeconds := 30
-	if ServiceTypeMap[vss.ServiceType] == "Unlock Vehicle" {
-		vehicle, err := v.client.Vehicles.GetVehicleByVIN(vss.VehicleID)
-		if err != nil {
-			return fmt.Errorf("failed to retrieve vehicle details for %s", vss.VehicleID)
+//   - if ctx is cancelled, ctx.Err() is returned immediately
+func (v *VehiclesService) EvaluateServiceStatusAuto(ctx context.Context, vss *VehicleServiceStatus) (err error) {
+	return v.EvaluateServiceStatus(ctx, vss, nil)
+}
+
+// EvaluateServiceStatus polls the Service API following policy until the operation behind vss finishes,
+// policy.Timeout elapses or ctx is cancelled, whichever happens first
+//
+// If policy is nil, the PollPolicy of the Client is used as in EvaluateServiceStatusAuto
+func (v *VehiclesService) EvaluateServiceStatus(ctx context.Context, vss *VehicleServiceStatus, policy *PollPolicy) (err error) {
+	if policy == nil {
+		if policy, err = v.pollPolicy(ctx, vss); err != nil {
+			return err
 		}
-		timeoutSeconds = vehicle.Attributes.UnlockTimeFrame
-		log.Printf("value of timeoutSeconds increased to %d to match the vehicle's unlockTimeFrame value", timeoutSeconds)
 	}
-	return v.EvaluateServiceStatus(vss, timeoutSeconds)
+	return v.pollServiceStatus(ctx, vss, policy, nil)
 }
 
-func (v *VehiclesService) EvaluateServiceStatus(vss *VehicleServiceStatus, timeoutSeconds int) (err error) {
-	c := 0
-	for {
-		if c == timeoutSeconds {
-			return fmt.Errorf("request timeout (%ds)", timeoutSeconds)
+// pollServiceStatus is the polling loop shared by EvaluateServiceStatus and Operation. onPoll (if not nil) receives every polled status
+func (v *VehiclesService) pollServiceStatus(ctx context.Context, vss *VehicleServiceStatus, policy *PollPolicy, onPoll func(vss *VehicleServiceStatus)) (err error) {
+	if err = policy.validate(); err != nil {
+		return err
+	}
+	policy = policy.withDefaults()
+	pollCtx := ctx
+	if policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		pollCtx, cancel = context.WithTimeout(ctx, policy.Timeout)
+		defer cancel()
+	}
+	// timedOut distinguishes the policy's deadline from the cancellation of ctx
+	timedOut := func() error {
+		if ctx.Err() != nil {
+			return ctx.Err()
 		}
-		if c > 0 {
-			if err = vss.Refresh(); err != nil {
+		return fmt.Errorf("request (%s) timeout (%s)", vss.ServiceType, policy.Timeout)
+	}
+
+	interval := policy.InitialInterval
+	for poll := 0; ; poll++ {
+		if poll > 0 {
+			if err = vss.Refresh(pollCtx); err != nil {
+				if pollCtx.Err() != nil {
+					return timedOut()
+				}
 				return
 			}
 		}
-		switch vss.Status {
-		case "Started":
-			time.Sleep(1 * time.Second)
-			c++
-			continue
-		case "MessageDelivered":
-			time.Sleep(1 * time.Second)
-			c++
+		v.client.logger().Log(LevelDebug, "polled service status", "vin", vss.VehicleID, "serviceType", vss.ServiceType, "customerServiceId", vss.CustomerServiceID, "status", vss.Status, "poll", poll)
+		if onPoll != nil {
+			onPoll(vss)
+		}
+		switch {
+		case !vss.Status.IsTerminal():
+			timer := time.NewTimer(interval)
+			select {
+			case <-pollCtx.Done():
+				timer.Stop()
+				return timedOut()
+			case <-timer.C:
+			}
+			interval = policy.next(interval)
 			continue
-		case "Successful":
+		case vss.Status.IsSuccess():
 			return nil
-		case "Failed":
-			return fmt.Errorf("request (%s) failed: %s", ServiceTypeMap[vss.ServiceType], vss.FailureReason)
+		case vss.Status == ServiceStatusFailed:
+			return fmt.Errorf("request (%s) failed: %s", vss.ServiceType, vss.FailureReason)
 		default:
-			return fmt.Errorf("request (%s) failed with status (%s): %s", ServiceTypeMap[vss.ServiceType], vss.Status, vss.FailureReason)
+			return fmt.Errorf("request (%s) failed with status (%s): %s", vss.ServiceType, vss.Status, vss.FailureReason)
 		}
 	}
 }
@@ -155,7 +172,7 @@ func (v *VehiclesService) EvaluateServiceStatus(vss *VehicleServiceStatus, timeo
 // This API requires sending the client's position. You have two options:
 //   - Share your position by passing a valid *Position struct.
 //   - Pass in `nil` and the actual own position of the car will be sent used
-func (v *VehiclesService) BlinkLights(vin string, position *Position) (status *VehicleServiceStatus, err error) {
+func (v *VehiclesService) BlinkLights(ctx context.Context, vin string, position *Position) (status *VehicleServiceStatus, err error) {
 	if vin == "" {
 		return nil, fmt.Errorf("vin must not be empty")
 	}
@@ -165,7 +182,7 @@ func (v *VehiclesService) BlinkLights(vin string, position *Position) (status *V
 	url := v.client.MakeURL(v.Endpoint, vin, "honk_blink", "lights")
 	if position == nil {
 		position = &Position{}
-		vehiclePosition, err := v.GetVehiclePositionByVIN(vin)
+		vehiclePosition, err := v.GetVehiclePositionByVIN(ctx, vin)
 		if err != nil {
 			return nil, err
 		}
@@ -177,7 +194,7 @@ func (v *VehiclesService) BlinkLights(vin string, position *Position) (status *V
 		"clientLatitude":  position.Latitude,
 		"clientLongitude": position.Longitude,
 	}
-	if _, err = v.client.Request.Post(url, payload, &status); err != nil {
+	if _, err = v.client.Request.Post(ctx, url, payload, &status); err != nil {
 		return nil, err
 	}
 	status.client = v.client
@@ -189,14 +206,14 @@ func (v *VehiclesService) BlinkLights(vin string, position *Position) (status *V
 // This API requires sending the client's position. You have two options:
 //   - Share your position by passing a valid *Position struct.
 //   - Pass in `nil` and the actual own position of the car will be sent used
-func (v *VehiclesService) HonkAndBlink(vin string, position *Position) (status *VehicleServiceStatus, err error) {
+func (v *VehiclesService) HonkAndBlink(ctx context.Context, vin string, position *Position) (status *VehicleServiceStatus, err error) {
 	if vin == "" {
 		return nil, fmt.Errorf("vin must not be empty")
 	}
 	url := v.client.MakeURL(v.Endpoint, vin, "honkAndBlink")
 	if position == nil {
 		position = &Position{}
-		vehiclePosition, err := v.GetVehiclePositionByVIN(vin)
+		vehiclePosition, err := v.GetVehiclePositionByVIN(ctx, vin)
 		if err != nil {
 			return nil, err
 		}
@@ -208,103 +225,129 @@ func (v *VehiclesService) HonkAndBlink(vin string, position *Position) (status *
 		"clientLatitude":  position.Latitude,
 		"clientLongitude": position.Longitude,
 	}
-	if _, err = v.client.Request.Post(url, payload, &status); err != nil {
+	if _, err = v.client.Request.Post(ctx, url, payload, &status); err != nil {
 		return nil, err
 	}
 	status.client = v.client
 	return
 }
 
-func (v *VehiclesService) LockVehicle(vin string) (status *VehicleServiceStatus, err error) {
+func (v *VehiclesService) LockVehicle(ctx context.Context, vin string) (status *VehicleServiceStatus, err error) {
 	if vin == "" {
 		return nil, fmt.Errorf("vin must not be empty")
 	}
 	url := v.client.MakeURL(v.Endpoint, vin, "lock")
-	if _, err = v.client.Request.Post(url, nil, &status); err != nil {
+	if _, err = v.client.Request.Post(ctx, url, nil, &status); err != nil {
 		return nil, err
 	}
 	status.client = v.client
 	return
 }
 
-func (v *VehiclesService) UnlockVehicle(vin string) (status *VehicleServiceStatus, err error) {
+func (v *VehiclesService) UnlockVehicle(ctx context.Context, vin string) (status *VehicleServiceStatus, err error) {
 	if vin == "" {
 		return nil, fmt.Errorf("vin must not be empty")
 	}
 	url := v.client.MakeURL(v.Endpoint, vin, "unlock")
-	if _, err = v.client.Request.Post(url, nil, &status); err != nil {
+	if _, err = v.client.Request.Post(ctx, url, nil, &status); err != nil {
 		return nil, err
 	}
 	status.client = v.client
 	return
 }
 
-func (v *VehiclesService) StartEngine(vin string) (status *VehicleServiceStatus, err error) {
+// Runtimes in minutes accepted by StartEngine. The engine stops by itself once the runtime elapsed
+const (
+	MinEngineRuntime = 1
+	MaxEngineRuntime = 15
+)
+
+// StartEngine starts the engine remotely for runtime minutes (MinEngineRuntime - MaxEngineRuntime)
+func (v *VehiclesService) StartEngine(ctx context.Context, vin string, runtime int) (status *VehicleServiceStatus, err error) {
 	if vin == "" {
 		return nil, fmt.Errorf("vin must not be empty")
 	}
+	if runtime < MinEngineRuntime || runtime > MaxEngineRuntime {
+		return nil, fmt.Errorf("engine runtime must be between %d and %d minutes, got %d", MinEngineRuntime, MaxEngineRuntime, runtime)
+	}
 	url := v.client.MakeURL(v.Endpoint, vin, "engine", "start")
-	if _, err = v.client.Request.Post(url, map[string]int{"runtime": 15}, &status); err != nil {
+	if _, err = v.client.Request.Post(ctx, url, map[string]int{"runtime": runtime}, &status); err != nil {
 		return nil, err
 	}
 	status.client = v.client
 	return
 }
 
-func (v *VehiclesService) StopEngine(vin string) (status *VehicleServiceStatus, err error) {
+func (v *VehiclesService) StopEngine(ctx context.Context, vin string) (status *VehicleServiceStatus, err error) {
 	if vin == "" {
 		return nil, fmt.Errorf("vin must not be empty")
 	}
 	url := v.client.MakeURL(v.Endpoint, vin, "engine", "stop")
-	if _, err = v.client.Request.Post(url, nil, &status); err != nil {
+	if _, err = v.client.Request.Post(ctx, url, nil, &status); err != nil {
 		return nil, err
 	}
 	status.client = v.client
 	return
 }
 
-func (v *VehiclesService) StartHeater(vin string) (status *VehicleServiceStatus, err error) {
+func (v *VehiclesService) StartHeater(ctx context.Context, vin string) (status *VehicleServiceStatus, err error) {
 	if vin == "" {
 		return nil, fmt.Errorf("vin must not be empty")
 	}
 	url := v.client.MakeURL(v.Endpoint, vin, "heater", "start")
-	if _, err = v.client.Request.Post(url, nil, &status); err != nil {
+	if _, err = v.client.Request.Post(ctx, url, nil, &status); err != nil {
 		return nil, err
 	}
 	status.client = v.client
 	return
 }
 
-func (v *VehiclesService) StopHeater(vin string) (status *VehicleServiceStatus, err error) {
+func (v *VehiclesService) StopHeater(ctx context.Context, vin string) (status *VehicleServiceStatus, err error) {
 	if vin == "" {
 		return nil, fmt.Errorf("vin must not be empty")
 	}
 	url := v.client.MakeURL(v.Endpoint, vin, "heater", "stop")
-	if _, err = v.client.Request.Post(url, nil, &status); err != nil {
+	if _, err = v.client.Request.Post(ctx, url, nil, &status); err != nil {
 		return nil, err
 	}
 	status.client = v.client
 	return
 }
 
-func (v *VehiclesService) StartPreclimatization(vin string) (status *VehicleServiceStatus, err error) {
+func (v *VehiclesService) StartPreclimatization(ctx context.Context, vin string) (status *VehicleServiceStatus, err error) {
 	if vin == "" {
 		return nil, fmt.Errorf("vin must not be empty")
 	}
 	url := v.client.MakeURL(v.Endpoint, vin, "preclimatization", "start")
-	if _, err = v.client.Request.Post(url, nil, &status); err != nil {
+	if _, err = v.client.Request.Post(ctx, url, nil, &status); err != nil {
 		return nil, err
 	}
 	status.client = v.client
 	return
 }
 
-func (v *VehiclesService) StopPreclimatization(vin string) (status *VehicleServiceStatus, err error) {
+func (v *VehiclesService) StopPreclimatization(ctx context.Context, vin string) (status *VehicleServiceStatus, err error) {
 	if vin == "" {
 		return nil, fmt.Errorf("vin must not be empty")
 	}
 	url := v.client.MakeURL(v.Endpoint, vin, "preclimatization", "stop")
-	if _, err = v.client.Request.Post(url, nil, &status); err != nil {
+	if _, err = v.client.Request.Post(ctx, url, nil, &status); err != nil {
+		return nil, err
+	}
+	status.client = v.client
+	return
+}
+
+// OverrideDelayCharging starts charging right away, ignoring the delay charging window of the current location
+//
+// Whether the car supports it is not checked. Vehicle.OverrideDelayCharging returns ErrVehicleUnsupported up front unless
+// its attributes advertise OverrideDelayChargingSupported, so prefer it over calling the service directly
+func (v *VehiclesService) OverrideDelayCharging(ctx context.Context, vin string) (status *VehicleServiceStatus, err error) {
+	if vin == "" {
+		return nil, fmt.Errorf("vin must not be empty")
+	}
+	url := v.client.MakeURL(v.Endpoint, vin, "overrideDelayCharging")
+	if _, err = v.client.Request.Post(ctx, url, nil, &status); err != nil {
 		return nil, err
 	}
 	status.client = v.client
@@ -314,12 +357,12 @@ func (v *VehiclesService) StopPreclimatization(vin string) (status *VehicleServi
 /*
 Charge Locations
 */
-func (v *VehiclesService) GetChargingLocations(vin string) (chargingLocations *ChargingLocations, err error) {
+func (v *VehiclesService) GetChargingLocations(ctx context.Context, vin string) (chargingLocations *ChargingLocations, err error) {
 	if vin == "" {
 		return nil, fmt.Errorf("vin must not be empty")
 	}
 	url := v.client.MakeURL(v.Endpoint, vin, "chargeLocations")
-	if _, err = v.client.Request.Get(url, &chargingLocations); err != nil {
+	if _, err = v.client.Request.Get(ctx, url, &chargingLocations); err != nil {
 		return nil, err
 	}
 	chargingLocations.client = v.client
@@ -329,7 +372,7 @@ func (v *VehiclesService) GetChargingLocations(vin string) (chargingLocations *C
 	return
 }
 
-func (v *VehiclesService) GetChargingLocation(vin, chargingId string) (chargingLocation *ChargingLocation, err error) {
+func (v *VehiclesService) GetChargingLocation(ctx context.Context, vin, chargingId string) (chargingLocation *ChargingLocation, err error) {
 	if vin == "" {
 		return nil, fmt.Errorf("vin must not be empty")
 	}
@@ -337,14 +380,14 @@ func (v *VehiclesService) GetChargingLocation(vin, chargingId string) (chargingL
 		return nil, fmt.Errorf("chargingId must not be empty")
 	}
 	url := v.client.MakeURL(v.Endpoint, vin, "chargeLocations", chargingId)
-	if _, err = v.client.Request.Get(url, &chargingLocation); err != nil {
+	if _, err = v.client.Request.Get(ctx, url, &chargingLocation); err != nil {
 		return nil, err
 	}
 	chargingLocation.client = v.client
 	return
 }
 
-func (v *VehiclesService) UpdateChargingLocation(vin, chargingId string, chargingLocation *ChargingLocation) (chargingLocationResponse *ChargingLocation, err error) {
+func (v *VehiclesService) UpdateChargingLocation(ctx context.Context, vin, chargingId string, chargingLocation *ChargingLocation) (chargingLocationResponse *ChargingLocation, err error) {
 	if vin == "" {
 		return nil, fmt.Errorf("vin must not be empty")
 	}
@@ -352,23 +395,59 @@ func (v *VehiclesService) UpdateChargingLocation(vin, chargingId string, chargin
 		return nil, fmt.Errorf("chargingId must not be empty")
 	}
 	url := v.client.MakeURL(v.Endpoint, vin, "chargeLocations", chargingId)
-	if _, err = v.client.Request.Put(url, &chargingLocation, &chargingLocationResponse); err != nil {
+	if _, err = v.client.Request.Put(ctx, url, &chargingLocation, &chargingLocationResponse); err != nil {
+		return nil, err
+	}
+	chargingLocationResponse.client = v.client
+	return
+}
+
+func (v *VehiclesService) CreateChargingLocation(ctx context.Context, vin string, chargingLocation *ChargingLocation) (chargingLocationResponse *ChargingLocation, err error) {
+	if vin == "" {
+		return nil, fmt.Errorf("vin must not be empty")
+	}
+	if chargingLocation == nil {
+		return nil, fmt.Errorf("charging location must not be empty")
+	}
+	if chargingLocation.Name == "" {
+		return nil, fmt.Errorf("charging location name must not be empty")
+	}
+	if chargingLocation.Position == nil {
+		return nil, fmt.Errorf("charging location position must not be empty")
+	}
+	if err = chargingLocation.Position.validate(); err != nil {
+		return nil, fmt.Errorf("charging location %w", err)
+	}
+	url := v.client.MakeURL(v.Endpoint, vin, "chargeLocations")
+	if _, err = v.client.Request.Post(ctx, url, chargingLocation, &chargingLocationResponse); err != nil {
 		return nil, err
 	}
 	chargingLocationResponse.client = v.client
 	return
 }
 
+func (v *VehiclesService) DeleteChargingLocation(ctx context.Context, vin, chargingId string) (err error) {
+	if vin == "" {
+		return fmt.Errorf("vin must not be empty")
+	}
+	if chargingId == "" {
+		return fmt.Errorf("chargingId must not be empty")
+	}
+	url := v.client.MakeURL(v.Endpoint, vin, "chargeLocations", chargingId)
+	_, err = v.client.Request.Delete(ctx, url)
+	return
+}
+
 /*
 	Utils
 */
 
-func (v *VehiclesService) RetrieveServiceStatus(vin, customerServiceId string) (status *VehicleServiceStatus, err error) {
+func (v *VehiclesService) RetrieveServiceStatus(ctx context.Context, vin, customerServiceId string) (status *VehicleServiceStatus, err error) {
 	if vin == "" || customerServiceId == "" {
 		return nil, fmt.Errorf("vin and customerServiceId must not be empty")
 	}
 	url := v.client.MakeURL(v.Endpoint, vin, "services", customerServiceId)
-	if _, err = v.client.Request.Get(url, &status); err != nil {
+	if _, err = v.client.Request.Get(ctx, url, &status); err != nil {
 		return nil, err
 	}
 	status.client = v.client
@@ -387,20 +466,20 @@ type Vehicle struct {
 	client                           *Client // added for interface simplification
 }
 
-func (v *Vehicle) RetrieveHyperlinks() (err error) {
+func (v *Vehicle) RetrieveHyperlinks(ctx context.Context) (err error) {
 	if v.Attributes == nil {
-		if v.Attributes, err = v.client.Vehicles.GetVehicleAttributesByVIN(v.VehicleID); err != nil {
+		if v.Attributes, err = v.client.Vehicles.GetVehicleAttributesByVIN(ctx, v.VehicleID); err != nil {
 			return
 		}
 	}
 	if v.Status == nil {
-		if v.Status, err = v.client.Vehicles.GetVehicleStatusByVIN(v.VehicleID); err != nil {
+		if v.Status, err = v.client.Vehicles.GetVehicleStatusByVIN(ctx, v.VehicleID); err != nil {
 			return
 		}
 	}
 	if !v.vehicleAccountRelationsRetrieved {
 		for _, url := range v.HyperlinkVehicleAccountRelations {
-			relation, err := v.client.AccountVehicleRelation.GetByHyperlink(url)
+			relation, err := v.client.AccountVehicleRelation.GetByHyperlink(ctx, url)
 			if err != nil {
 				return err
 			}
@@ -414,74 +493,231 @@ func (v *Vehicle) RetrieveHyperlinks() (err error) {
 /*
 Actions/Operations
 */
-func (v Vehicle) BlinkLights(position *Position) (status *VehicleServiceStatus, err error) {
-	return v.client.Vehicles.BlinkLights(v.VehicleID, position)
+
+func (v Vehicle) BlinkLights(ctx context.Context, position *Position) (op *Operation, err error) {
+	vss, err := v.client.Vehicles.BlinkLights(ctx, v.VehicleID, position)
+	if err != nil {
+		return nil, err
+	}
+	return v.client.Vehicles.Track(ctx, vss, nil), nil
+}
+
+func (v *Vehicle) GetPosition(ctx context.Context) (position *VehiclePosition, err error) {
+	return v.client.Vehicles.GetVehiclePositionByVIN(ctx, v.VehicleID)
 }
 
-func (v *Vehicle) GetPosition() (position *VehiclePosition, err error) {
-	return v.client.Vehicles.GetVehiclePositionByVIN(v.VehicleID)
+func (v *Vehicle) GetTrips(ctx context.Context) (trips *VehicleTrips, err error) {
+	return v.client.Vehicles.GetVehicleTripsByVIN(ctx, v.VehicleID)
 }
 
-func (v *Vehicle) GetTrips() (trips *VehicleTrips, err error) {
-	return v.client.Vehicles.GetVehicleTripsByVIN(v.VehicleID)
+func (v *Vehicle) QueryTrips(ctx context.Context, query *TripsQuery) (trips *VehicleTrips, err error) {
+	return v.client.Vehicles.QueryVehicleTripsByVIN(ctx, v.VehicleID, query)
 }
 
-func (v *Vehicle) Lock() (status *VehicleServiceStatus, err error) {
+func (v *Vehicle) Lock(ctx context.Context) (op *Operation, err error) {
 	if !v.IsLockSupported() {
-		return nil, fmt.Errorf("lock/unlock is not supported by %s [%s]", v.Attributes.RegistrationNumber, v.Attributes.Vin)
+		return nil, fmt.Errorf("lock/unlock is not supported by %s [%s]: %w", v.Attributes.RegistrationNumber, v.Attributes.Vin, ErrVehicleUnsupported)
 	}
-	return v.client.Vehicles.LockVehicle(v.VehicleID)
+	vss, err := v.client.Vehicles.LockVehicle(ctx, v.VehicleID)
+	if err != nil {
+		return nil, err
+	}
+	return v.client.Vehicles.Track(ctx, vss, nil), nil
 }
 
-func (v Vehicle) UnlockVehicle() (status *VehicleServiceStatus, err error) {
+func (v Vehicle) UnlockVehicle(ctx context.Context) (op *Operation, err error) {
 	if !v.IsUnlockSupported() {
-		return nil, fmt.Errorf("lock/unlock is not supported by %s [%s]", v.Attributes.RegistrationNumber, v.Attributes.Vin)
+		return nil, fmt.Errorf("lock/unlock is not supported by %s [%s]: %w", v.Attributes.RegistrationNumber, v.Attributes.Vin, ErrVehicleUnsupported)
+	}
+	vss, err := v.client.Vehicles.UnlockVehicle(ctx, v.VehicleID)
+	if err != nil {
+		return nil, err
 	}
-	return v.client.Vehicles.UnlockVehicle(v.VehicleID)
+	return v.client.Vehicles.Track(ctx, vss, nil), nil
 }
 
-func (v Vehicle) StartEngine() (status *VehicleServiceStatus, err error) {
+// StartEngine starts the engine for runtime minutes. See VehiclesService.StartEngine
+func (v Vehicle) StartEngine(ctx context.Context, runtime int) (op *Operation, err error) {
 	if !v.IsEngineStartSupported() {
-		return nil, fmt.Errorf("engine start/stop is not supported by %s [%s]", v.Attributes.RegistrationNumber, v.Attributes.Vin)
+		return nil, fmt.Errorf("engine start/stop is not supported by %s [%s]: %w", v.Attributes.RegistrationNumber, v.Attributes.Vin, ErrVehicleUnsupported)
+	}
+	vss, err := v.client.Vehicles.StartEngine(ctx, v.VehicleID, runtime)
+	if err != nil {
+		return nil, err
 	}
-	return v.client.Vehicles.StartEngine(v.VehicleID)
+	return v.client.Vehicles.Track(ctx, vss, nil), nil
 }
 
-func (v Vehicle) StopEngine() (status *VehicleServiceStatus, err error) {
+func (v Vehicle) StopEngine(ctx context.Context) (op *Operation, err error) {
 	if !v.IsEngineStartSupported() {
-		return nil, fmt.Errorf("engine start/stop is not supported by %s [%s]", v.Attributes.RegistrationNumber, v.Attributes.Vin)
+		return nil, fmt.Errorf("engine start/stop is not supported by %s [%s]: %w", v.Attributes.RegistrationNumber, v.Attributes.Vin, ErrVehicleUnsupported)
 	}
-	return v.client.Vehicles.StopEngine(v.VehicleID)
+	vss, err := v.client.Vehicles.StopEngine(ctx, v.VehicleID)
+	if err != nil {
+		return nil, err
+	}
+	return v.client.Vehicles.Track(ctx, vss, nil), nil
 }
 
-func (v Vehicle) StartHeater() (status *VehicleServiceStatus, err error) {
+func (v Vehicle) StartHeater(ctx context.Context) (op *Operation, err error) {
 	switch {
 	case v.IsHeaterSupported():
-		return v.client.Vehicles.StartHeater(v.VehicleID)
+		vss, err := v.client.Vehicles.StartHeater(ctx, v.VehicleID)
+		if err != nil {
+			return nil, err
+		}
+		return v.client.Vehicles.Track(ctx, vss, nil), nil
 	case v.IsPreclimatizationSupported():
-		return v.client.Vehicles.StartPreclimatization(v.VehicleID)
+		vss, err := v.client.Vehicles.StartPreclimatization(ctx, v.VehicleID)
+		if err != nil {
+			return nil, err
+		}
+		return v.client.Vehicles.Track(ctx, vss, nil), nil
 	default:
-		return nil, fmt.Errorf("heater is not supported by %s [%s]", v.Attributes.RegistrationNumber, v.Attributes.Vin)
+		return nil, fmt.Errorf("heater is not supported by %s [%s]: %w", v.Attributes.RegistrationNumber, v.Attributes.Vin, ErrVehicleUnsupported)
 	}
 }
 
-func (v Vehicle) StopHeater() (status *VehicleServiceStatus, err error) {
+func (v Vehicle) StopHeater(ctx context.Context) (op *Operation, err error) {
 	switch {
 	case v.IsHeaterSupported():
-		return v.client.Vehicles.StopHeater(v.VehicleID)
+		vss, err := v.client.Vehicles.StopHeater(ctx, v.VehicleID)
+		if err != nil {
+			return nil, err
+		}
+		return v.client.Vehicles.Track(ctx, vss, nil), nil
 	case v.IsPreclimatizationSupported():
-		return v.client.Vehicles.StopPreclimatization(v.VehicleID)
+		vss, err := v.client.Vehicles.StopPreclimatization(ctx, v.VehicleID)
+		if err != nil {
+			return nil, err
+		}
+		return v.client.Vehicles.Track(ctx, vss, nil), nil
 	default:
-		return nil, fmt.Errorf("heater is not supported by %s [%s]", v.Attributes.RegistrationNumber, v.Attributes.Vin)
+		return nil, fmt.Errorf("heater is not supported by %s [%s]: %w", v.Attributes.RegistrationNumber, v.Attributes.Vin, ErrVehicleUnsupported)
+	}
+}
+
+// OverrideDelayCharging starts charging right away. See VehiclesService.OverrideDelayCharging
+func (v Vehicle) OverrideDelayCharging(ctx context.Context) (op *Operation, err error) {
+	if !v.IsOverrideDelayChargingSupported() {
+		return nil, fmt.Errorf("override delay charging is not supported by %s [%s]: %w", v.Attributes.RegistrationNumber, v.Attributes.Vin, ErrVehicleUnsupported)
+	}
+	vss, err := v.client.Vehicles.OverrideDelayCharging(ctx, v.VehicleID)
+	if err != nil {
+		return nil, err
+	}
+	return v.client.Vehicles.Track(ctx, vss, nil), nil
+}
+
+// SetDelayCharging replaces the delay charging window of the location. Unset StartTime or StopTime keep their current value
+//
+// Enabling it fails with an error wrapping ErrLimitExceeded if MaxActiveDelayChargingLocations other locations delay charging already
+func (v Vehicle) SetDelayCharging(ctx context.Context, chargingId string, delayCharging *DelayCharging) (chargingLocation *ChargingLocation, err error) {
+	if delayCharging.Enabled {
+		if err = v.checkActiveDelayChargingLimit(ctx, chargingId); err != nil {
+			return nil, err
+		}
+	}
+	return v.updateChargingLocation(ctx, chargingId, func(cl *ChargingLocation) error {
+		dc := *delayCharging
+		if cl.DelayCharging != nil {
+			if dc.StartTime.IsZero() {
+				dc.StartTime = cl.DelayCharging.StartTime
+			}
+			if dc.StopTime.IsZero() {
+				dc.StopTime = cl.DelayCharging.StopTime
+			}
+		}
+		if dc.Enabled && (dc.StartTime.IsZero() || dc.StopTime.IsZero()) {
+			return fmt.Errorf("delay charging requires both a start and a stop time")
+		}
+		cl.DelayCharging = &dc
+		return nil
+	})
+}
+
+// AddChargingLocation creates a charging location. Its delay charging is subject to MaxActiveDelayChargingLocations as in SetDelayCharging
+func (v Vehicle) AddChargingLocation(ctx context.Context, chargingLocation *ChargingLocation) (*ChargingLocation, error) {
+	if chargingLocation == nil {
+		return nil, fmt.Errorf("charging location must not be empty")
+	}
+	if chargingLocation.DelayCharging != nil && chargingLocation.DelayCharging.Enabled {
+		if err := v.checkActiveDelayChargingLimit(ctx, ""); err != nil {
+			return nil, err
+		}
+	}
+	return v.client.Vehicles.CreateChargingLocation(ctx, v.VehicleID, chargingLocation)
+}
+
+func (v Vehicle) RemoveChargingLocation(ctx context.Context, chargingId string) error {
+	return v.client.Vehicles.DeleteChargingLocation(ctx, v.VehicleID, chargingId)
+}
+
+func (v Vehicle) RenameChargingLocation(ctx context.Context, chargingId, name string) (*ChargingLocation, error) {
+	if name == "" {
+		return nil, fmt.Errorf("charging location name must not be empty")
+	}
+	return v.updateChargingLocation(ctx, chargingId, func(cl *ChargingLocation) error {
+		cl.Name = name
+		return nil
+	})
+}
+
+// MoveChargingLocation replaces the coordinates and the address of the location
+func (v Vehicle) MoveChargingLocation(ctx context.Context, chargingId string, position *ChargingLocationPosition) (*ChargingLocation, error) {
+	if position == nil {
+		return nil, fmt.Errorf("charging location position must not be empty")
+	}
+	if err := position.validate(); err != nil {
+		return nil, fmt.Errorf("charging location %w", err)
+	}
+	return v.updateChargingLocation(ctx, chargingId, func(cl *ChargingLocation) error {
+		cl.Position = position
+		return nil
+	})
+}
+
+// SetPlugInReminder turns on or off the reminder to plug in the car when it's parked at the location
+func (v Vehicle) SetPlugInReminder(ctx context.Context, chargingId string, enabled bool) (*ChargingLocation, error) {
+	return v.updateChargingLocation(ctx, chargingId, func(cl *ChargingLocation) error {
+		cl.PlugInReminderEnabled = enabled
+		return nil
+	})
+}
+
+// updateChargingLocation retrieves the location, applies update and writes the whole location back
+func (v Vehicle) updateChargingLocation(ctx context.Context, chargingId string, update func(cl *ChargingLocation) error) (*ChargingLocation, error) {
+	cl, err := v.client.Vehicles.GetChargingLocation(ctx, v.VehicleID, chargingId)
+	if err != nil {
+		return nil, err
 	}
+	if err = update(cl); err != nil {
+		return nil, err
+	}
+	return v.client.Vehicles.UpdateChargingLocation(ctx, v.VehicleID, chargingId, cl)
 }
 
-func (v Vehicle) SetDelayCharging(chargingId string, delayCharging *DelayCharging) (chargingLocation *ChargingLocation, err error) {
-	cl := ChargingLocation{
-		Status:        "Accepted",
-		DelayCharging: delayCharging,
+// checkActiveDelayChargingLimit returns an error wrapping ErrLimitExceeded if MaxActiveDelayChargingLocations locations
+// other than chargingId delay charging already. A limit of 0 is considered unknown and is not enforced
+func (v Vehicle) checkActiveDelayChargingLimit(ctx context.Context, chargingId string) error {
+	limit := v.Attributes.MaxActiveDelayChargingLocations
+	if limit <= 0 {
+		return nil
+	}
+	chargingLocations, err := v.client.Vehicles.GetChargingLocations(ctx, v.VehicleID)
+	if err != nil {
+		return err
 	}
-	return v.client.Vehicles.UpdateChargingLocation(v.VehicleID, chargingId, &cl)
+	active := 0
+	for _, cl := range chargingLocations.ChargingLocations {
+		if cl.ID() != chargingId && cl.DelayCharging != nil && cl.DelayCharging.Enabled {
+			active++
+		}
+	}
+	if active >= limit {
+		return fmt.Errorf("delay charging of %s [%s] is limited to %d active charging locations: %w", v.Attributes.RegistrationNumber, v.Attributes.Vin, limit, ErrLimitExceeded)
+	}
+	return nil
 }
 
 /*
@@ -500,7 +736,7 @@ func (v Vehicle) IsHeaterOn() bool {
 	case "off":
 		return false
 	default:
-		log.Printf("IsHeaterOn :: unexpected response: %s\n", v.Status.Heater.Status)
+		v.client.logger().Log(LevelWarn, "unexpected heater status", "vin", v.VehicleID, "status", v.Status.Heater.Status)
 		return false
 	}
 }
@@ -541,60 +777,65 @@ func (v Vehicle) IsEngineStartSupported() bool {
 	return v.Attributes.EngineStartSupported
 }
 
+func (v Vehicle) IsOverrideDelayChargingSupported() bool {
+	return v.Attributes.OverrideDelayChargingSupported
+}
+
 type VehicleAttributes struct {
-	EngineCode                             string   `json:"engineCode"`
-	ExteriorCode                           string   `json:"exteriorCode"`
-	InteriorCode                           string   `json:"interiorCode"`
-	TyreDimensionCode                      string   `json:"tyreDimensionCode"`
-	TyreInflationPressureLightCode         string   `json:"tyreInflationPressureLightCode"`
-	TyreInflationPressureHeavyCode         string   `json:"tyreInflationPressureHeavyCode"`
-	GearboxCode                            string   `json:"gearboxCode"`
-	FuelType                               string   `json:"fuelType"`
-	FuelTankVolume                         int      `json:"fuelTankVolume"`
-	GrossWeight                            int      `json:"grossWeight"`
-	ModelYear                              int      `json:"modelYear"`
-	VehicleType                            string   `json:"vehicleType"`
-	VehicleTypeCode                        string   `json:"vehicleTypeCode"`
-	NumberOfDoors                          int      `json:"numberOfDoors"`
-	RegistrationNumber                     string   `json:"registrationNumber"`
-	CarLocatorDistance                     int      `json:"carLocatorDistance"`
-	HonkAndBlinkDistance                   int      `json:"honkAndBlinkDistance"`
-	BCallAssistanceNumber                  string   `json:"bCallAssistanceNumber"`
-	CarLocatorSupported                    bool     `json:"carLocatorSupported"`
-	HonkAndBlinkSupported                  bool     `json:"honkAndBlinkSupported"`
-	HonkAndBlinkVersionsSupported          []string `json:"honkAndBlinkVersionsSupported"`
-	RemoteHeaterSupported                  bool     `json:"remoteHeaterSupported"`
-	UnlockSupported                        bool     `json:"unlockSupported"`
-	LockSupported                          bool     `json:"lockSupported"`
-	JournalLogSupported                    bool     `json:"journalLogSupported"`
-	AssistanceCallSupported                bool     `json:"assistanceCallSupported"`
-	UnlockTimeFrame                        int      `json:"unlockTimeFrame"`
-	VerificationTimeFrame                  int      `json:"verificationTimeFrame"`
-	TimeFullyAccessible                    int      `json:"timeFullyAccessible"`
-	TimePartiallyAccessible                int      `json:"timePartiallyAccessible"`
-	SubscriptionType                       string   `json:"subscriptionType"`
-	SubscriptionStartDate                  string   `json:"subscriptionStartDate"`
-	SubscriptionEndDate                    string   `json:"subscriptionEndDate"`
-	ServerVersion                          string   `json:"serverVersion"`
-	Vin                                    string   `json:"VIN"`
-	JournalLogEnabled                      bool     `json:"journalLogEnabled"`
-	HighVoltageBatterySupported            bool     `json:"highVoltageBatterySupported"`
-	MaxActiveDelayChargingLocations        int      `json:"maxActiveDelayChargingLocations"`
-	PreclimatizationSupported              bool     `json:"preclimatizationSupported"`
-	SendPOIToVehicleVersionsSupported      []string `json:"sendPOIToVehicleVersionsSupported"`
-	ClimatizationCalendarVersionsSupported []string `json:"climatizationCalendarVersionsSupported"`
-	ClimatizationCalendarMaxTimers         int      `json:"climatizationCalendarMaxTimers"`
-	VehiclePlatform                        string   `json:"vehiclePlatform"`
-	VinLower                               string   `json:"vin"`
-	OverrideDelayChargingSupported         bool     `json:"overrideDelayChargingSupported"`
-	EngineStartSupported                   bool     `json:"engineStartSupported"`
-	StatusParkedIndoorSupported            bool     `json:"status.parkedIndoor.supported"`
+	EngineCode                             string    `json:"engineCode"`
+	ExteriorCode                           string    `json:"exteriorCode"`
+	InteriorCode                           string    `json:"interiorCode"`
+	TyreDimensionCode                      string    `json:"tyreDimensionCode"`
+	TyreInflationPressureLightCode         string    `json:"tyreInflationPressureLightCode"`
+	TyreInflationPressureHeavyCode         string    `json:"tyreInflationPressureHeavyCode"`
+	GearboxCode                            string    `json:"gearboxCode"`
+	FuelType                               string    `json:"fuelType"`
+	FuelTankVolume                         int       `json:"fuelTankVolume"`
+	GrossWeight                            int       `json:"grossWeight"`
+	ModelYear                              int       `json:"modelYear"`
+	VehicleType                            string    `json:"vehicleType"`
+	VehicleTypeCode                        string    `json:"vehicleTypeCode"`
+	NumberOfDoors                          int       `json:"numberOfDoors"`
+	RegistrationNumber                     string    `json:"registrationNumber"`
+	CarLocatorDistance                     int       `json:"carLocatorDistance"`
+	HonkAndBlinkDistance                   int       `json:"honkAndBlinkDistance"`
+	BCallAssistanceNumber                  string    `json:"bCallAssistanceNumber"`
+	CarLocatorSupported                    bool      `json:"carLocatorSupported"`
+	HonkAndBlinkSupported                  bool      `json:"honkAndBlinkSupported"`
+	HonkAndBlinkVersionsSupported          []string  `json:"honkAndBlinkVersionsSupported"`
+	RemoteHeaterSupported                  bool      `json:"remoteHeaterSupported"`
+	UnlockSupported                        bool      `json:"unlockSupported"`
+	LockSupported                          bool      `json:"lockSupported"`
+	JournalLogSupported                    bool      `json:"journalLogSupported"`
+	AssistanceCallSupported                bool      `json:"assistanceCallSupported"`
+	UnlockTimeFrame                        int       `json:"unlockTimeFrame"`
+	VerificationTimeFrame                  int       `json:"verificationTimeFrame"`
+	TimeFullyAccessible                    int       `json:"timeFullyAccessible"`
+	TimePartiallyAccessible                int       `json:"timePartiallyAccessible"`
+	SubscriptionType                       string    `json:"subscriptionType"`
+	SubscriptionStartDate                  Timestamp `json:"subscriptionStartDate"`
+	SubscriptionEndDate                    Timestamp `json:"subscriptionEndDate"`
+	ServerVersion                          string    `json:"serverVersion"`
+	Vin                                    string    `json:"VIN"`
+	JournalLogEnabled                      bool      `json:"journalLogEnabled"`
+	HighVoltageBatterySupported            bool      `json:"highVoltageBatterySupported"`
+	MaxActiveDelayChargingLocations        int       `json:"maxActiveDelayChargingLocations"`
+	PreclimatizationSupported              bool      `json:"preclimatizationSupported"`
+	SendPOIToVehicleVersionsSupported      []string  `json:"sendPOIToVehicleVersionsSupported"`
+	ClimatizationCalendarVersionsSupported []string  `json:"climatizationCalendarVersionsSupported"`
+	ClimatizationCalendarMaxTimers         int       `json:"climatizationCalendarMaxTimers"`
+	VehiclePlatform                        string    `json:"vehiclePlatform"`
+	VinLower                               string    `json:"vin"`
+	OverrideDelayChargingSupported         bool      `json:"overrideDelayChargingSupported"`
+	EngineStartSupported                   bool      `json:"engineStartSupported"`
+	StatusParkedIndoorSupported            bool      `json:"status.parkedIndoor.supported"`
 	Country                                struct {
 		Iso2 string `json:"iso2"`
 	} `json:"country"`
 	client *Client // added for interface simplification
 }
 
+// VIN returns the vehicle's VIN from whichever attribute was populated by the API, or an empty string if neither was
 func (va VehicleAttributes) VIN() string {
 	switch {
 	case len(va.Vin) > 0:
@@ -602,103 +843,85 @@ func (va VehicleAttributes) VIN() string {
 	case len(va.VinLower) > 0:
 		return va.VinLower
 	default:
-		log.Panicln("vin could not be retrieved from VehicleAttributes")
+		va.client.logger().Log(LevelError, "vin could not be retrieved from VehicleAttributes", "registrationNumber", va.RegistrationNumber)
 		return ""
 	}
 }
 
 type VehicleStatus struct {
-	AverageFuelConsumption          float64  `json:"averageFuelConsumption"`
-	AverageFuelConsumptionTimestamp string   `json:"averageFuelConsumptionTimestamp"`
-	AverageSpeed                    int      `json:"averageSpeed"`
-	AverageSpeedTimestamp           string   `json:"averageSpeedTimestamp"`
-	BrakeFluid                      string   `json:"brakeFluid"`
-	BrakeFluidTimestamp             string   `json:"brakeFluidTimestamp"`
-	BulbFailures                    []string `json:"bulbFailures"`
-	BulbFailuresTimestamp           string   `json:"bulbFailuresTimestamp"`
-	CarLocked                       bool     `json:"carLocked"`
-	CarLockedTimestamp              string   `json:"carLockedTimestamp"`
-	ConnectionStatus                string   `json:"connectionStatus"`
-	ConnectionStatusTimestamp       string   `json:"connectionStatusTimestamp"`
-	DistanceToEmpty                 int      `json:"distanceToEmpty"`
-	DistanceToEmptyTimestamp        string   `json:"distanceToEmptyTimestamp"`
+	AverageFuelConsumption          float64   `json:"averageFuelConsumption"`
+	AverageFuelConsumptionTimestamp Timestamp `json:"averageFuelConsumptionTimestamp"`
+	AverageSpeed                    int       `json:"averageSpeed"`
+	AverageSpeedTimestamp           Timestamp `json:"averageSpeedTimestamp"`
+	BrakeFluid                      string    `json:"brakeFluid"`
+	BrakeFluidTimestamp             Timestamp `json:"brakeFluidTimestamp"`
+	BulbFailures                    []string  `json:"bulbFailures"`
+	BulbFailuresTimestamp           Timestamp `json:"bulbFailuresTimestamp"`
+	CarLocked                       bool      `json:"carLocked"`
+	CarLockedTimestamp              Timestamp `json:"carLockedTimestamp"`
+	ConnectionStatus                string    `json:"connectionStatus"`
+	ConnectionStatusTimestamp       Timestamp `json:"connectionStatusTimestamp"`
+	DistanceToEmpty                 int       `json:"distanceToEmpty"`
+	DistanceToEmptyTimestamp        Timestamp `json:"distanceToEmptyTimestamp"`
 	Doors                           struct {
-		TailgateOpen       bool   `json:"tailgateOpen"`
-		RearRightDoorOpen  bool   `json:"rearRightDoorOpen"`
-		RearLeftDoorOpen   bool   `json:"rearLeftDoorOpen"`
-		FrontRightDoorOpen bool   `json:"frontRightDoorOpen"`
-		FrontLeftDoorOpen  bool   `json:"frontLeftDoorOpen"`
-		HoodOpen           bool   `json:"hoodOpen"`
-		Timestamp          string `json:"timestamp"`
+		TailgateOpen       bool      `json:"tailgateOpen"`
+		RearRightDoorOpen  bool      `json:"rearRightDoorOpen"`
+		RearLeftDoorOpen   bool      `json:"rearLeftDoorOpen"`
+		FrontRightDoorOpen bool      `json:"frontRightDoorOpen"`
+		FrontLeftDoorOpen  bool      `json:"frontLeftDoorOpen"`
+		HoodOpen           bool      `json:"hoodOpen"`
+		Timestamp          Timestamp `json:"timestamp"`
 	} `json:"doors"`
-	EngineRunning            bool   `json:"engineRunning"`
-	EngineRunningTimestamp   string `json:"engineRunningTimestamp"`
-	FuelAmount               int    `json:"fuelAmount"`
-	FuelAmountLevel          int    `json:"fuelAmountLevel"`
-	FuelAmountLevelTimestamp string `json:"fuelAmountLevelTimestamp"`
-	FuelAmountTimestamp      string `json:"fuelAmountTimestamp"`
-	Heater                   struct {
-		SeatSelection struct {
-			FrontDriverSide    bool `json:"frontDriverSide"`
-			FrontPassengerSide bool `json:"frontPassengerSide"`
-			RearDriverSide     bool `json:"rearDriverSide"`
-			RearPassengerSide  bool `json:"rearPassengerSide"`
-			RearMid            bool `json:"rearMid"`
-		} `json:"seatSelection"`
-		Status string `json:"status"`
-		Timer1 struct {
-			Time  string `json:"time"`
-			State bool   `json:"state"`
-		} `json:"timer1"`
-		Timer2 struct {
-			Time  string `json:"time"`
-			State bool   `json:"state"`
-		} `json:"timer2"`
-		Timestamp string `json:"timestamp"`
-	} `json:"heater"`
-	HvBattery struct {
-		HvBatteryChargeStatusDerived          string `json:"hvBatteryChargeStatusDerived"`
-		HvBatteryChargeStatusDerivedTimestamp string `json:"hvBatteryChargeStatusDerivedTimestamp"`
-		HvBatteryChargeModeStatus             string `json:"hvBatteryChargeModeStatus"`
-		HvBatteryChargeModeStatusTimestamp    string `json:"hvBatteryChargeModeStatusTimestamp"`
-		HvBatteryChargeStatus                 string `json:"hvBatteryChargeStatus"`
-		HvBatteryChargeStatusTimestamp        string `json:"hvBatteryChargeStatusTimestamp"`
-		HvBatteryLevel                        int    `json:"hvBatteryLevel"`
-		HvBatteryLevelTimestamp               string `json:"hvBatteryLevelTimestamp"`
-		DistanceToHVBatteryEmpty              int    `json:"distanceToHVBatteryEmpty"`
-		DistanceToHVBatteryEmptyTimestamp     string `json:"distanceToHVBatteryEmptyTimestamp"`
-		HvBatteryChargeWarning                string `json:"hvBatteryChargeWarning"`
-		HvBatteryChargeWarningTimestamp       string `json:"hvBatteryChargeWarningTimestamp"`
-		TimeToHVBatteryFullyCharged           int    `json:"timeToHVBatteryFullyCharged"`
-		TimeToHVBatteryFullyChargedTimestamp  string `json:"timeToHVBatteryFullyChargedTimestamp"`
+	EngineRunning            bool      `json:"engineRunning"`
+	EngineRunningTimestamp   Timestamp `json:"engineRunningTimestamp"`
+	FuelAmount               int       `json:"fuelAmount"`
+	FuelAmountLevel          int       `json:"fuelAmountLevel"`
+	FuelAmountLevelTimestamp Timestamp `json:"fuelAmountLevelTimestamp"`
+	FuelAmountTimestamp      Timestamp `json:"fuelAmountTimestamp"`
+	Heater                   Heater    `json:"heater"`
+	HvBattery                struct {
+		HvBatteryChargeStatusDerived          string    `json:"hvBatteryChargeStatusDerived"`
+		HvBatteryChargeStatusDerivedTimestamp Timestamp `json:"hvBatteryChargeStatusDerivedTimestamp"`
+		HvBatteryChargeModeStatus             string    `json:"hvBatteryChargeModeStatus"`
+		HvBatteryChargeModeStatusTimestamp    Timestamp `json:"hvBatteryChargeModeStatusTimestamp"`
+		HvBatteryChargeStatus                 string    `json:"hvBatteryChargeStatus"`
+		HvBatteryChargeStatusTimestamp        Timestamp `json:"hvBatteryChargeStatusTimestamp"`
+		HvBatteryLevel                        int       `json:"hvBatteryLevel"`
+		HvBatteryLevelTimestamp               Timestamp `json:"hvBatteryLevelTimestamp"`
+		DistanceToHVBatteryEmpty              int       `json:"distanceToHVBatteryEmpty"`
+		DistanceToHVBatteryEmptyTimestamp     Timestamp `json:"distanceToHVBatteryEmptyTimestamp"`
+		HvBatteryChargeWarning                string    `json:"hvBatteryChargeWarning"`
+		HvBatteryChargeWarningTimestamp       Timestamp `json:"hvBatteryChargeWarningTimestamp"`
+		TimeToHVBatteryFullyCharged           int       `json:"timeToHVBatteryFullyCharged"`
+		TimeToHVBatteryFullyChargedTimestamp  Timestamp `json:"timeToHVBatteryFullyChargedTimestamp"`
 	} `json:"hvBattery"`
-	Odometer                           int    `json:"odometer"`
-	OdometerTimestamp                  string `json:"odometerTimestamp"`
-	ParkedIndoor                       bool   `json:"parkedIndoor"`
-	ParkedIndoorTimestamp              string `json:"parkedIndoorTimestamp"`
-	RemoteClimatizationStatus          string `json:"remoteClimatizationStatus"`
-	RemoteClimatizationStatusTimestamp string `json:"remoteClimatizationStatusTimestamp"`
-	ServiceWarningStatus               string `json:"serviceWarningStatus"`
-	ServiceWarningStatusTimestamp      string `json:"serviceWarningStatusTimestamp"`
+	Odometer                           int       `json:"odometer"`
+	OdometerTimestamp                  Timestamp `json:"odometerTimestamp"`
+	ParkedIndoor                       bool      `json:"parkedIndoor"`
+	ParkedIndoorTimestamp              Timestamp `json:"parkedIndoorTimestamp"`
+	RemoteClimatizationStatus          string    `json:"remoteClimatizationStatus"`
+	RemoteClimatizationStatusTimestamp Timestamp `json:"remoteClimatizationStatusTimestamp"`
+	ServiceWarningStatus               string    `json:"serviceWarningStatus"`
+	ServiceWarningStatusTimestamp      Timestamp `json:"serviceWarningStatusTimestamp"`
 	TheftAlarm                         struct {
-		Longitude float64 `json:"longitude"`
-		Latitude  float64 `json:"latitude"`
-		Timestamp string  `json:"timestamp"`
+		Longitude float64   `json:"longitude"`
+		Latitude  float64   `json:"latitude"`
+		Timestamp Timestamp `json:"timestamp"`
 	} `json:"theftAlarm"`
-	TimeFullyAccessibleUntil     string `json:"timeFullyAccessibleUntil"`
-	TimePartiallyAccessibleUntil string `json:"timePartiallyAccessibleUntil"`
-	TripMeter1                   int    `json:"tripMeter1"`
-	TripMeter1Timestamp          string `json:"tripMeter1Timestamp"`
-	TripMeter2                   int    `json:"tripMeter2"`
-	TripMeter2Timestamp          string `json:"tripMeter2Timestamp"`
-	WasherFluidLevel             string `json:"washerFluidLevel"`
-	WasherFluidLevelTimestamp    string `json:"washerFluidLevelTimestamp"`
+	TimeFullyAccessibleUntil     Timestamp `json:"timeFullyAccessibleUntil"`
+	TimePartiallyAccessibleUntil Timestamp `json:"timePartiallyAccessibleUntil"`
+	TripMeter1                   int       `json:"tripMeter1"`
+	TripMeter1Timestamp          Timestamp `json:"tripMeter1Timestamp"`
+	TripMeter2                   int       `json:"tripMeter2"`
+	TripMeter2Timestamp          Timestamp `json:"tripMeter2Timestamp"`
+	WasherFluidLevel             string    `json:"washerFluidLevel"`
+	WasherFluidLevelTimestamp    Timestamp `json:"washerFluidLevelTimestamp"`
 	Windows                      struct {
-		FrontLeftWindowOpen  bool   `json:"frontLeftWindowOpen"`
-		FrontRightWindowOpen bool   `json:"frontRightWindowOpen"`
-		Timestamp            string `json:"timestamp"`
-		RearLeftWindowOpen   bool   `json:"rearLeftWindowOpen"`
-		RearRightWindowOpen  bool   `json:"rearRightWindowOpen"`
+		FrontLeftWindowOpen  bool      `json:"frontLeftWindowOpen"`
+		FrontRightWindowOpen bool      `json:"frontRightWindowOpen"`
+		Timestamp            Timestamp `json:"timestamp"`
+		RearLeftWindowOpen   bool      `json:"rearLeftWindowOpen"`
+		RearRightWindowOpen  bool      `json:"rearRightWindowOpen"`
 	} `json:"windows"`
 	client *Client // added for interface simplification
 }
@@ -710,64 +933,62 @@ type VehiclePosition struct {
 }
 
 type VehicleTrips struct {
-	Trips []struct {
-		ID           int    `json:"id"`
-		Name         string `json:"name"`
-		Category     string `json:"category"`
-		UserNotes    string `json:"userNotes"`
-		Trip         string `json:"trip"`
-		RouteDetails struct {
-			Route          string `json:"route"`
-			TotalWaypoints int    `json:"totalWaypoints"`
-			BoundingBox    struct {
-				MinLongitude float64 `json:"minLongitude"`
-				MinLatitude  float64 `json:"minLatitude"`
-				MaxLongitude float64 `json:"maxLongitude"`
-				MaxLatitude  float64 `json:"maxLatitude"`
-			} `json:"boundingBox"`
-		} `json:"routeDetails,omitempty"`
-		TripDetails []struct {
-			FuelConsumption        float64 `json:"fuelConsumption"`
-			ElectricalConsumption  float64 `json:"electricalConsumption"`
-			ElectricalRegeneration float64 `json:"electricalRegeneration"`
-			Distance               float64 `json:"distance"`
-			StartOdometer          int     `json:"startOdometer"`
-			StartTime              string  `json:"startTime"`
-			StartPosition          struct {
-				Longitude       float64 `json:"longitude"`
-				Latitude        float64 `json:"latitude"`
-				StreetAddress   string  `json:"streetAddress"`
-				PostalCode      string  `json:"postalCode"`
-				City            string  `json:"city"`
-				ISO2CountryCode string  `json:"ISO2CountryCode"`
-				Region          string  `json:"Region"`
-			} `json:"startPosition"`
-			EndOdometer int    `json:"endOdometer"`
-			EndTime     string `json:"endTime"`
-			EndPosition struct {
-				Longitude       float64 `json:"longitude"`
-				Latitude        float64 `json:"latitude"`
-				StreetAddress   string  `json:"streetAddress"`
-				PostalCode      string  `json:"postalCode"`
-				City            string  `json:"city"`
-				ISO2CountryCode string  `json:"ISO2CountryCode"`
-				Region          string  `json:"Region"`
-			} `json:"endPosition"`
-		} `json:"tripDetails"`
-	} `json:"trips"`
+	Trips  []Trip  `json:"trips"`
 	client *Client // added for interface simplification
 }
 
+type Trip struct {
+	ID           int          `json:"id"`
+	Name         string       `json:"name"`
+	Category     TripCategory `json:"category"`
+	UserNotes    string       `json:"userNotes"`
+	Trip         string       `json:"trip"`
+	RouteDetails struct {
+		Route          string `json:"route"`
+		TotalWaypoints int    `json:"totalWaypoints"`
+		BoundingBox    struct {
+			MinLongitude float64 `json:"minLongitude"`
+			MinLatitude  float64 `json:"minLatitude"`
+			MaxLongitude float64 `json:"maxLongitude"`
+			MaxLatitude  float64 `json:"maxLatitude"`
+		} `json:"boundingBox"`
+	} `json:"routeDetails,omitempty"`
+	TripDetails []TripDetail `json:"tripDetails"`
+}
+
+type TripDetail struct {
+	FuelConsumption        float64      `json:"fuelConsumption"`
+	ElectricalConsumption  float64      `json:"electricalConsumption"`
+	ElectricalRegeneration float64      `json:"electricalRegeneration"`
+	Distance               float64      `json:"distance"`
+	StartOdometer          int          `json:"startOdometer"`
+	StartTime              Timestamp    `json:"startTime"`
+	StartPosition          TripPosition `json:"startPosition"`
+	EndOdometer            int          `json:"endOdometer"`
+	EndTime                Timestamp    `json:"endTime"`
+	EndPosition            TripPosition `json:"endPosition"`
+}
+
+type TripPosition struct {
+	Longitude       float64 `json:"longitude"`
+	Latitude        float64 `json:"latitude"`
+	StreetAddress   string  `json:"streetAddress"`
+	PostalCode      string  `json:"postalCode"`
+	City            string  `json:"city"`
+	ISO2CountryCode string  `json:"ISO2CountryCode"`
+	Region          string  `json:"Region"`
+}
+
 type VehicleServiceStatus struct {
-	Status            string  `json:"status"`
-	StatusTimestamp   string  `json:"statusTimestamp"`
-	StartTime         string  `json:"startTime"`
-	ServiceType       string  `json:"serviceType"`
-	FailureReason     string  `json:"failureReason"` // TODO: no idea about the actual type
-	Service           string  `json:"service"`       // hyperlink
-	VehicleID         string  `json:"vehicleId"`     // VIN
-	CustomerServiceID string  `json:"customerServiceId"`
-	client            *Client // added for interface simplification
+	Status            ServiceStatus `json:"status"`
+	StatusTimestamp   Timestamp     `json:"statusTimestamp"`
+	StartTime         Timestamp     `json:"startTime"`
+	ServiceType       ServiceType   `json:"serviceType"`
+	FailureReason     string        `json:"failureReason"` // TODO: no idea about the actual type
+	Service           string        `json:"service"`       // hyperlink
+	VehicleID         string        `json:"vehicleId"`     // VIN
+	CustomerServiceID string        `json:"customerServiceId"`
+	client            *Client       // added for interface simplification
 }
 
 /*
@@ -775,8 +996,8 @@ type VehicleServiceStatus struct {
 	which is pretty weird because i'm passing a regular string to a simple function.
 */
 
-func (vss *VehicleServiceStatus) Refresh() error {
-	vssNew, err := vss.client.Vehicles.GetServiceStatus(vss.Service)
+func (vss *VehicleServiceStatus) Refresh(ctx context.Context) error {
+	vssNew, err := vss.client.Vehicles.GetServiceStatus(ctx, vss.Service)
 	if err != nil {
 		return err
 	}
@@ -6,18 +6,26 @@ package main
 */
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
 	"path"
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"strconv"
 	"strings"
+	"syscall"
 	"text/tabwriter"
+	"time"
 	"unicode"
 
 	vocdriver "github.com/theriverman/VolvoOnCall"
+	"github.com/theriverman/VolvoOnCall/voctest"
 	"github.com/tidwall/gjson"
 	"github.com/urfave/cli/v2"
 	"golang.org/x/text/language"
@@ -25,14 +33,14 @@ import (
 )
 
 func actionCars(c *cli.Context) error {
-	account, err := client.CustomerAccount.GetAccount()
+	account, err := client.CustomerAccount.GetAccount(c.Context)
 	if err != nil {
 		return err
 	}
-	if err = account.RetrieveHyperlinks(); err != nil {
+	if err = account.RetrieveHyperlinks(c.Context); err != nil {
 		return err
 	}
-	vehicles, err := account.GetVehicles()
+	vehicles, err := account.GetVehicles(c.Context)
 	if err != nil {
 		return err
 	}
@@ -40,7 +48,7 @@ func actionCars(c *cli.Context) error {
 	fmt.Printf("Cars associated to Volvo Account(%s):\n", account.Username)
 	fmt.Println("-----------------------------------" + strings.Repeat("-", len(account.Username)))
 	for _, vehicle := range vehicles {
-		if err = vehicle.RetrieveHyperlinks(); err != nil {
+		if err = vehicle.RetrieveHyperlinks(c.Context); err != nil {
 			return err
 		}
 		fmt.Printf("  * %s (%s)\n", vehicle.VehicleID, vehicle.Attributes.RegistrationNumber)
@@ -50,6 +58,9 @@ func actionCars(c *cli.Context) error {
 }
 
 func actionRegister(c *cli.Context) error {
+	if Config.Password == "" && Config.PasswordCommand == "" {
+		return fmt.Errorf("either --password or --password-command must be set")
+	}
 	homeDirPath, err := os.UserHomeDir()
 	if err != nil {
 		return err
@@ -59,7 +70,7 @@ func actionRegister(c *cli.Context) error {
 }
 
 func actionAttributes(c *cli.Context) error {
-	vehicle, err := client.Vehicles.GetVehicleByVIN(selectedVin)
+	vehicle, err := client.Vehicles.GetVehicleByVIN(c.Context, selectedVin)
 	if err != nil {
 		return err
 	}
@@ -102,7 +113,7 @@ func actionAttributes(c *cli.Context) error {
 }
 
 func actionPosition(c *cli.Context) error {
-	pos, err := client.Vehicles.GetVehiclePositionByVIN(selectedVin)
+	pos, err := client.Vehicles.GetVehiclePositionByVIN(c.Context, selectedVin)
 	if err != nil {
 		return err
 	}
@@ -120,8 +131,7 @@ func actionPosition(c *cli.Context) error {
 	fmt.Printf("  - Longitude:\t%.15f\n", pos.Position.Longitude)
 	fmt.Printf("  - Latitude:\t%.15f\n", pos.Position.Latitude)
 	fmt.Printf("  - Timestamp:\t%s\n", pos.Position.Timestamp)
-	// fmt.Printf("  - Speed:\t%s\n", (pos.Position.Speed))   // TODO: enable when actual type is known
-	// fmt.Printf("  - Heading:\t%s\n", pos.Position.Heading) // TODO: enable when actual type is known
+	printSpeedAndHeading(pos.Position)
 	fmt.Printf("  - Maps URL:\thttps://www.google.com/maps/place/%.15f,%.15f\n", pos.Position.Latitude, pos.Position.Longitude)
 
 	// CALCULATED POSITION
@@ -130,8 +140,7 @@ func actionPosition(c *cli.Context) error {
 		fmt.Printf("  - Longitude:\t%.15f\n", pos.CalculatedPosition.Longitude)
 		fmt.Printf("  - Latitude:\t%.15f\n", pos.CalculatedPosition.Latitude)
 		fmt.Printf("  - Timestamp:\t%s\n", pos.CalculatedPosition.Timestamp)
-		// fmt.Printf("  - Speed:\t%s\n", pos.CalculatedPosition.Speed)     // TODO: enable when actual type is known
-		// fmt.Printf("  - Heading:\t%s\n", pos.CalculatedPosition.Heading) // TODO: enable when actual type is known
+		printSpeedAndHeading(pos.CalculatedPosition)
 		fmt.Printf("  - Maps URL:\thttps://www.google.com/maps/place/%.15f,%.15f\n", pos.CalculatedPosition.Latitude, pos.CalculatedPosition.Longitude)
 	} else {
 		fmt.Println("\nCalculated Position is not available")
@@ -140,8 +149,21 @@ func actionPosition(c *cli.Context) error {
 	return nil
 }
 
+func printSpeedAndHeading(p vocdriver.Position) {
+	if p.Speed != nil {
+		fmt.Printf("  - Speed:\t%s\n", p.Speed)
+	} else {
+		fmt.Println("  - Speed:\tnot reported")
+	}
+	if p.Heading != nil {
+		fmt.Printf("  - Heading:\t%s\n", p.Heading)
+	} else {
+		fmt.Println("  - Heading:\tnot reported")
+	}
+}
+
 func actionStatus(c *cli.Context) error {
-	vehicle, err := client.Vehicles.GetVehicleByVIN(selectedVin)
+	vehicle, err := client.Vehicles.GetVehicleByVIN(c.Context, selectedVin)
 	if err != nil {
 		return err
 	}
@@ -166,8 +188,9 @@ func actionStatus(c *cli.Context) error {
 	}
 
 	// default mode - print select attributes
-	fmt.Printf("Average Fuel Consumption:\t%.1f l/100 km\n", vehicle.Status.AverageFuelConsumption/10)
-	fmt.Printf("Average Speed:\t\t\t%d km/h\n", vehicle.Status.AverageSpeed)
+	readings := vehicle.Status.Readings()
+	fmt.Printf("Average Fuel Consumption:\t%s\n", readings.AverageFuelConsumption)
+	fmt.Printf("Average Speed:\t\t\t%s\n", readings.AverageSpeed)
 	fmt.Printf("Brake Fluid:\t\t\t%s\n", vehicle.Status.BrakeFluid)
 	if len(vehicle.Status.BulbFailures) > 0 {
 		fmt.Println("Bulb Failures:")
@@ -176,7 +199,7 @@ func actionStatus(c *cli.Context) error {
 		}
 	}
 	fmt.Printf("Car Locked:\t\t\t%t\n", vehicle.Status.CarLocked)
-	fmt.Printf("Distance to Empty:\t\t%d km\n", vehicle.Status.DistanceToEmpty)
+	fmt.Printf("Distance to Empty:\t\t%.0f km\n", readings.DistanceToEmpty.Kilometers())
 	doors := vehicle.Status.Doors
 	if doors.HoodOpen || doors.FrontLeftDoorOpen || doors.FrontRightDoorOpen || doors.RearLeftDoorOpen || doors.RearRightDoorOpen || doors.TailgateOpen {
 		fmt.Printf("Doors Open:\t\t\t%t\n", vehicle.Status.CarLocked)
@@ -190,13 +213,29 @@ func actionStatus(c *cli.Context) error {
 		fmt.Println("Doors Open:\t\t\tNone")
 	}
 	fmt.Printf("Engine Running:\t\t\t%t\n", vehicle.Status.EngineRunning)
-	fmt.Printf("Fuel Amount [l]:\t\t%d l\n", vehicle.Status.FuelAmount)
+	fmt.Printf("Fuel Amount [l]:\t\t%.0f l\n", readings.FuelAmount.Liters())
 	fmt.Printf("Fuel Amount [%%]:\t\t%d%%\n", vehicle.Status.FuelAmountLevel)
 	return actionPosition(c)
 }
 
 func actionTrips(c *cli.Context) error {
-	trips, err := client.Vehicles.GetVehicleTripsByVIN(selectedVin)
+	var err error
+	if tripsSince != "" {
+		if tripsQuery.Since, err = parseDate(tripsSince); err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+	}
+	if tripsUntil != "" {
+		if tripsQuery.Until, err = parseDate(tripsUntil); err != nil {
+			return fmt.Errorf("invalid --until: %w", err)
+		}
+	}
+	if tripsCategory != "" {
+		if tripsQuery.Category, err = vocdriver.ParseTripCategory(tripsCategory); err != nil {
+			return err
+		}
+	}
+	trips, err := client.Vehicles.QueryVehicleTripsByVIN(c.Context, selectedVin, &tripsQuery)
 	if err != nil {
 		return err
 	}
@@ -213,7 +252,7 @@ func actionTrips(c *cli.Context) error {
 	p := message.NewPrinter(language.English)
 	for i := len(trips.Trips) - 1; i >= 0; i-- { // ! reverse-loop !
 		trip := trips.Trips[i]
-		fmt.Printf("Trip %d\n", i+1)
+		fmt.Printf("Trip %d\n", tripsQuery.Offset+i+1)
 		fmt.Printf("  ID: %d\n", trip.ID)
 		fmt.Printf("  Name: %s\n", trip.Name)
 		fmt.Printf("  Category: %s\n", trip.Category)
@@ -222,12 +261,13 @@ func actionTrips(c *cli.Context) error {
 			fmt.Printf("  Trip Detail %d\n", ii+1)
 			fmt.Printf("    Start Time: %s\n", tripDetail.StartTime)
 			fmt.Printf("    End   Time: %s\n", tripDetail.EndTime)
-			fmt.Printf("    Fuel Consumption: %.3f l\n", tripDetail.FuelConsumption/100)
-			fmt.Printf("    Electrical Consumption: %.3f kWh\n", float64(tripDetail.ElectricalConsumption)/1000)
-			fmt.Printf("    Electrical Regeneration: %.3f kWh\n", float64(tripDetail.ElectricalRegeneration)/1000)
-			fmt.Printf("    Distance: %.3f km\n", tripDetail.Distance/1000)
-			p.Printf("    Start Odometer: %d km\n", tripDetail.StartOdometer/1000)
-			p.Printf("    End   Odometer: %d km\n", tripDetail.EndOdometer/1000)
+			readings := tripDetail.Readings()
+			fmt.Printf("    Fuel Consumption: %.3f l\n", readings.FuelConsumption.Liters())
+			fmt.Printf("    Electrical Consumption: %.3f kWh\n", readings.ElectricalConsumption.KilowattHours())
+			fmt.Printf("    Electrical Regeneration: %.3f kWh\n", readings.ElectricalRegeneration.KilowattHours())
+			fmt.Printf("    Distance: %.3f km\n", readings.Distance.Kilometers())
+			p.Printf("    Start Odometer: %d km\n", int(readings.StartOdometer.Kilometers()))
+			p.Printf("    End   Odometer: %d km\n", int(readings.EndOdometer.Kilometers()))
 			fmt.Printf("    Start Position: %+v\n", tripDetail.StartPosition)
 			fmt.Printf("    End   Position: %+v\n", tripDetail.EndPosition)
 			fmt.Println()
@@ -237,89 +277,395 @@ func actionTrips(c *cli.Context) error {
 	return nil
 }
 
+func actionEditTrip(c *cli.Context) error {
+	if c.Args().Len() == 0 {
+		return fmt.Errorf("you must provide a trip id. see --help for more details")
+	}
+	tripId, err := parseTripId(c.Args().First())
+	if err != nil {
+		return err
+	}
+	if err = parseTrailingFlags(c, c.Args().Tail()); err != nil {
+		return err
+	}
+	update := vocdriver.TripUpdate{}
+	if c.IsSet("name") {
+		update.Name = &tripName
+	}
+	if c.IsSet("category") {
+		category, err := vocdriver.ParseTripCategory(tripCategory)
+		if err != nil {
+			return err
+		}
+		update.Category = &category
+	}
+	if c.IsSet("notes") {
+		update.UserNotes = &tripNotes
+	}
+	vehicle, err := client.Vehicles.GetVehicleByVIN(c.Context, selectedVin)
+	if err != nil {
+		return err
+	}
+	trip, err := vehicle.UpdateTrip(c.Context, tripId, &update)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Trip %d\n", trip.ID)
+	fmt.Printf("  Name: %s\n", trip.Name)
+	fmt.Printf("  Category: %s\n", trip.Category)
+	fmt.Printf("  User Notes: %s\n", trip.UserNotes)
+	return nil
+}
+
+func actionDeleteTrip(c *cli.Context) error {
+	if c.Args().Len() != 1 {
+		return fmt.Errorf("you must provide a trip id. see --help for more details")
+	}
+	tripId, err := parseTripId(c.Args().First())
+	if err != nil {
+		return err
+	}
+	vehicle, err := client.Vehicles.GetVehicleByVIN(c.Context, selectedVin)
+	if err != nil {
+		return err
+	}
+	return vehicle.DeleteTrip(c.Context, tripId)
+}
+
+func parseTripId(s string) (int, error) {
+	tripId, err := strconv.Atoi(s)
+	if err != nil || tripId <= 0 {
+		return 0, fmt.Errorf("trip id must be a positive number, got %q", s)
+	}
+	return tripId, nil
+}
+
 func actionLock(c *cli.Context) error {
-	status, err := client.Vehicles.LockVehicle(selectedVin)
+	status, err := client.Vehicles.LockVehicle(c.Context, selectedVin)
 	if err != nil {
 		return err
 	}
-	return client.Vehicles.EvaluateServiceStatusAuto(status)
+	return evaluateServiceStatus(c, status)
 }
 
 func actionUnlock(c *cli.Context) error {
-	status, err := client.Vehicles.UnlockVehicle(selectedVin)
+	status, err := client.Vehicles.UnlockVehicle(c.Context, selectedVin)
 	if err != nil {
 		return err
 	}
 	fmt.Println("Within 2 minutes press once gently on the rubberised pressure plate underneath the boot lid handle to unlock the car")
-	return client.Vehicles.EvaluateServiceStatusAuto(status)
+	return evaluateServiceStatus(c, status)
 }
 
 func actionStartHeater(c *cli.Context) error {
-	status, err := client.Vehicles.StartHeater(selectedVin)
+	status, err := client.Vehicles.StartHeater(c.Context, selectedVin)
 	if err != nil {
 		return err
 	}
-	return client.Vehicles.EvaluateServiceStatusAuto(status)
+	return evaluateServiceStatus(c, status)
 }
 
 func actionStopHeater(c *cli.Context) error {
-	status, err := client.Vehicles.StopHeater(selectedVin)
+	status, err := client.Vehicles.StopHeater(c.Context, selectedVin)
 	if err != nil {
 		return err
 	}
-	return client.Vehicles.EvaluateServiceStatusAuto(status)
+	return evaluateServiceStatus(c, status)
+}
+
+func actionListHeaterTimers(c *cli.Context) error {
+	vehicle, err := client.Vehicles.GetVehicleByVIN(c.Context, selectedVin)
+	if err != nil {
+		return err
+	}
+	timers, err := vehicle.GetHeaterTimers(c.Context)
+	if err != nil {
+		return err
+	}
+	for i, timer := range timers {
+		fmt.Printf("Timer %d: %s (enabled: %t)\n", i+1, timer.Time, timer.State)
+	}
+	seats := vehicle.Status.Heater.SeatSelection
+	fmt.Printf("Heated seats:\n")
+	fmt.Printf("  - Front driver side:\t\t%t\n", seats.FrontDriverSide)
+	fmt.Printf("  - Front passenger side:\t%t\n", seats.FrontPassengerSide)
+	fmt.Printf("  - Rear driver side:\t\t%t\n", seats.RearDriverSide)
+	fmt.Printf("  - Rear passenger side:\t%t\n", seats.RearPassengerSide)
+	fmt.Printf("  - Rear mid:\t\t\t%t\n", seats.RearMid)
+	return nil
+}
+
+func actionSetHeaterTimer(c *cli.Context) error {
+	if c.Args().Len() != 2 {
+		return fmt.Errorf("you must provide: timer number + time. see --help for more details")
+	}
+	timer, err := parseHeaterTimer(c.Args().First())
+	if err != nil {
+		return err
+	}
+	clockTime, err := vocdriver.ParseClockTime(c.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	vehicle, err := client.Vehicles.GetVehicleByVIN(c.Context, selectedVin)
+	if err != nil {
+		return err
+	}
+	timers, err := vehicle.GetHeaterTimers(c.Context)
+	if err != nil {
+		return err
+	}
+	ht := timers[timer-1] // keep current state
+	ht.Time = clockTime
+	if _, err = vehicle.SetHeaterTimer(c.Context, timer, &ht); err != nil {
+		return err
+	}
+	fmt.Printf("Timer %d: %s (enabled: %t)\n", timer, ht.Time, ht.State)
+	return nil
+}
+
+func actionEnableHeaterTimer(c *cli.Context) error {
+	return setHeaterTimerState(c, true)
+}
+
+func actionDisableHeaterTimer(c *cli.Context) error {
+	return setHeaterTimerState(c, false)
+}
+
+func setHeaterTimerState(c *cli.Context, enabled bool) error {
+	if c.Args().Len() != 1 {
+		return fmt.Errorf("you must provide a timer number. see --help for more details")
+	}
+	timer, err := parseHeaterTimer(c.Args().First())
+	if err != nil {
+		return err
+	}
+	vehicle, err := client.Vehicles.GetVehicleByVIN(c.Context, selectedVin)
+	if err != nil {
+		return err
+	}
+	var ht *vocdriver.HeaterTimer
+	if enabled {
+		ht, err = vehicle.EnableHeaterTimer(c.Context, timer)
+	} else {
+		ht, err = vehicle.DisableHeaterTimer(c.Context, timer)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Timer %d: %s (enabled: %t)\n", timer, ht.Time, ht.State)
+	return nil
+}
+
+func parseHeaterTimer(s string) (int, error) {
+	timer, err := strconv.Atoi(s)
+	if err != nil || timer < 1 || timer > vocdriver.HeaterTimerCount {
+		return 0, fmt.Errorf("timer must be a number between 1 and %d, got %q", vocdriver.HeaterTimerCount, s)
+	}
+	return timer, nil
+}
+
+func actionListClimatizationTimers(c *cli.Context) error {
+	vehicle, err := client.Vehicles.GetVehicleByVIN(c.Context, selectedVin)
+	if err != nil {
+		return err
+	}
+	calendar, err := vehicle.GetClimatizationCalendar(c.Context)
+	if err != nil {
+		return err
+	}
+	if len(calendar.Timers) == 0 {
+		fmt.Println("No departure timers")
+	}
+	for _, ct := range calendar.Timers {
+		printClimatizationTimer(ct)
+	}
+	fmt.Printf("Departure timers: %d of %d\n", len(calendar.Timers), vehicle.Attributes.ClimatizationCalendarMaxTimers)
+	return nil
+}
+
+func actionAddClimatizationTimer(c *cli.Context) error {
+	if c.Args().Len() != 2 {
+		return fmt.Errorf("you must provide: days + departure time. see --help for more details")
+	}
+	weekdays, err := vocdriver.ParseWeekdays(c.Args().First())
+	if err != nil {
+		return err
+	}
+	clockTime, err := vocdriver.ParseClockTime(c.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	vehicle, err := client.Vehicles.GetVehicleByVIN(c.Context, selectedVin)
+	if err != nil {
+		return err
+	}
+	ct, err := vehicle.AddClimatizationTimer(c.Context, vocdriver.ClimatizationTimer{Weekdays: weekdays, Time: clockTime, Enabled: true})
+	if err != nil {
+		return err
+	}
+	printClimatizationTimer(*ct)
+	return nil
+}
+
+func actionUpdateClimatizationTimer(c *cli.Context) error {
+	if c.Args().Len() != 3 {
+		return fmt.Errorf("you must provide: timer id + days + departure time. see --help for more details")
+	}
+	weekdays, err := vocdriver.ParseWeekdays(c.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	clockTime, err := vocdriver.ParseClockTime(c.Args().Get(2))
+	if err != nil {
+		return err
+	}
+	return updateClimatizationTimer(c, func(ct *vocdriver.ClimatizationTimer) {
+		ct.Weekdays = weekdays
+		ct.Time = clockTime
+	})
+}
+
+func actionEnableClimatizationTimer(c *cli.Context) error {
+	return updateClimatizationTimer(c, func(ct *vocdriver.ClimatizationTimer) { ct.Enabled = true })
+}
+
+func actionDisableClimatizationTimer(c *cli.Context) error {
+	return updateClimatizationTimer(c, func(ct *vocdriver.ClimatizationTimer) { ct.Enabled = false })
+}
+
+func actionRemoveClimatizationTimer(c *cli.Context) error {
+	if c.Args().Len() != 1 {
+		return fmt.Errorf("you must provide a timer id. see --help for more details")
+	}
+	id, err := strconv.Atoi(c.Args().First())
+	if err != nil {
+		return fmt.Errorf("invalid timer id %q", c.Args().First())
+	}
+	vehicle, err := client.Vehicles.GetVehicleByVIN(c.Context, selectedVin)
+	if err != nil {
+		return err
+	}
+	return vehicle.DeleteClimatizationTimer(c.Context, id)
+}
+
+// updateClimatizationTimer applies update to the timer whose ID is the first argument
+func updateClimatizationTimer(c *cli.Context, update func(ct *vocdriver.ClimatizationTimer)) error {
+	if c.Args().Len() == 0 {
+		return fmt.Errorf("you must provide a timer id. see --help for more details")
+	}
+	id, err := strconv.Atoi(c.Args().First())
+	if err != nil {
+		return fmt.Errorf("invalid timer id %q", c.Args().First())
+	}
+	vehicle, err := client.Vehicles.GetVehicleByVIN(c.Context, selectedVin)
+	if err != nil {
+		return err
+	}
+	calendar, err := vehicle.GetClimatizationCalendar(c.Context)
+	if err != nil {
+		return err
+	}
+	ct := calendar.Timer(id)
+	if ct == nil {
+		return fmt.Errorf("departure timer %d was not found. Use the list command to find timers", id)
+	}
+	update(ct)
+	if _, err = vehicle.UpdateClimatizationTimer(c.Context, *ct); err != nil {
+		return err
+	}
+	printClimatizationTimer(*ct)
+	return nil
+}
+
+func printClimatizationTimer(ct vocdriver.ClimatizationTimer) {
+	fmt.Printf("Timer %d: %s at %s (enabled: %t)\n", ct.ID, ct.Weekdays, ct.Time, ct.Enabled)
+}
+
+func actionSendPOI(c *cli.Context) error {
+	vehicle, err := client.Vehicles.GetVehicleByVIN(c.Context, selectedVin)
+	if err != nil {
+		return err
+	}
+	op, err := vehicle.SendPOI(c.Context, &poi)
+	if err != nil {
+		return err
+	}
+	return waitForOperation(c, op)
 }
 
 func actionStartEngine(c *cli.Context) error {
-	status, err := client.Vehicles.StartEngine(selectedVin)
+	if engineAutoStop < 0 || engineAutoStop > time.Duration(engineRuntime)*time.Minute {
+		return fmt.Errorf("--auto-stop must be between 0 and the --runtime of %d minutes", engineRuntime)
+	}
+	status, err := client.Vehicles.StartEngine(c.Context, selectedVin, engineRuntime)
 	if err != nil {
 		return err
 	}
-	return client.Vehicles.EvaluateServiceStatusAuto(status)
+	if err = evaluateServiceStatus(c, status); err != nil || engineAutoStop == 0 {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	fmt.Printf("Stopping the engine in %s. Press Ctrl+C to stop it now\n", engineAutoStop)
+	select {
+	case <-time.After(engineAutoStop):
+	case <-ctx.Done():
+	}
+	stop()
+	if c.Context.Err() != nil {
+		return c.Context.Err()
+	}
+	status, err = client.Vehicles.StopEngine(c.Context, selectedVin)
+	if err != nil {
+		return err
+	}
+	return evaluateServiceStatus(c, status)
 }
 
 func actionStopEngine(c *cli.Context) error {
-	status, err := client.Vehicles.StopEngine(selectedVin)
+	status, err := client.Vehicles.StopEngine(c.Context, selectedVin)
 	if err != nil {
 		return err
 	}
-	return client.Vehicles.EvaluateServiceStatusAuto(status)
+	return evaluateServiceStatus(c, status)
 }
 
 func actionStartPreclimatization(c *cli.Context) error {
-	status, err := client.Vehicles.StartPreclimatization(selectedVin)
+	status, err := client.Vehicles.StartPreclimatization(c.Context, selectedVin)
 	if err != nil {
 		return err
 	}
-	return client.Vehicles.EvaluateServiceStatusAuto(status)
+	return evaluateServiceStatus(c, status)
 }
 
 func actionStopPreclimatization(c *cli.Context) error {
-	status, err := client.Vehicles.StopPreclimatization(selectedVin)
+	status, err := client.Vehicles.StopPreclimatization(c.Context, selectedVin)
 	if err != nil {
 		return err
 	}
-	return client.Vehicles.EvaluateServiceStatusAuto(status)
+	return evaluateServiceStatus(c, status)
 }
 
 func actionBlink(c *cli.Context) error {
-	status, err := client.Vehicles.BlinkLights(selectedVin, nil)
+	status, err := client.Vehicles.BlinkLights(c.Context, selectedVin, nil)
 	if err != nil {
 		return err
 	}
-	return client.Vehicles.EvaluateServiceStatusAuto(status)
+	return evaluateServiceStatus(c, status)
 }
 
 func actionHonk(c *cli.Context) error {
-	status, err := client.Vehicles.HonkAndBlink(selectedVin, nil)
+	status, err := client.Vehicles.HonkAndBlink(c.Context, selectedVin, nil)
 	if err != nil {
 		return err
 	}
-	return client.Vehicles.EvaluateServiceStatusAuto(status)
+	return evaluateServiceStatus(c, status)
 }
 
 func actionListChargingLocations(c *cli.Context) error {
-	chargingLocations, err := client.Vehicles.GetChargingLocations(selectedVin)
+	chargingLocations, err := client.Vehicles.GetChargingLocations(c.Context, selectedVin)
 	if err != nil {
 		return err
 	}
@@ -347,7 +693,7 @@ func actionGetChargingLocationById(c *cli.Context) error {
 	if c.Args().Len() == 0 {
 		return fmt.Errorf("you must provide a charging location id. see --help for more details")
 	}
-	cl, err := client.Vehicles.GetChargingLocation(selectedVin, c.Args().First())
+	cl, err := client.Vehicles.GetChargingLocation(c.Context, selectedVin, c.Args().First())
 	if err != nil {
 		return err
 	}
@@ -369,10 +715,79 @@ func actionGetChargingLocationById(c *cli.Context) error {
 	return nil
 }
 
+func actionAddChargingLocation(c *cli.Context) error {
+	vehicle, err := client.Vehicles.GetVehicleByVIN(c.Context, selectedVin)
+	if err != nil {
+		return err
+	}
+	cl, err := vehicle.AddChargingLocation(c.Context, &vocdriver.ChargingLocation{
+		Name:     chargingLocationName,
+		Position: &chargingLocationPosition,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Charging location %s added with ID %s\n", cl.Name, cl.ID())
+	return nil
+}
+
+func actionRenameChargingLocation(c *cli.Context) error {
+	if c.Args().Len() != 2 {
+		return fmt.Errorf("you must provide: charging location id + name. see --help for more details")
+	}
+	vehicle, err := client.Vehicles.GetVehicleByVIN(c.Context, selectedVin)
+	if err != nil {
+		return err
+	}
+	_, err = vehicle.RenameChargingLocation(c.Context, c.Args().First(), c.Args().Get(1))
+	return err
+}
+
+func actionRemoveChargingLocation(c *cli.Context) error {
+	if c.Args().Len() == 0 {
+		return fmt.Errorf("you must provide a charging location id. see --help for more details")
+	}
+	vehicle, err := client.Vehicles.GetVehicleByVIN(c.Context, selectedVin)
+	if err != nil {
+		return err
+	}
+	return vehicle.RemoveChargingLocation(c.Context, c.Args().First())
+}
+
+func actionEnablePlugInReminder(c *cli.Context) error {
+	return setPlugInReminder(c, true)
+}
+
+func actionDisablePlugInReminder(c *cli.Context) error {
+	return setPlugInReminder(c, false)
+}
+
+func setPlugInReminder(c *cli.Context, enabled bool) error {
+	if c.Args().Len() == 0 {
+		return fmt.Errorf("you must provide a charging location id. see --help for more details")
+	}
+	vehicle, err := client.Vehicles.GetVehicleByVIN(c.Context, selectedVin)
+	if err != nil {
+		return err
+	}
+	_, err = vehicle.SetPlugInReminder(c.Context, c.Args().First(), enabled)
+	return err
+}
+
+func actionOverrideDelayCharging(c *cli.Context) error {
+	vehicle, err := client.Vehicles.GetVehicleByVIN(c.Context, selectedVin)
+	if err != nil {
+		return err
+	}
+	op, err := vehicle.OverrideDelayCharging(c.Context)
+	if err != nil {
+		return err
+	}
+	return waitForOperation(c, op)
+}
+
 func actionEnableDelayCharging(c *cli.Context) error {
-	var (
-		chargingId, startTime, stopTime string
-	)
+	var chargingId string
 	dc := vocdriver.DelayCharging{
 		Enabled: true,
 	}
@@ -380,31 +795,27 @@ func actionEnableDelayCharging(c *cli.Context) error {
 	case 0:
 		return fmt.Errorf("you must provide a charging location id. see --help for more details")
 	case 1:
-		chargingId = c.Args().First()
-		cl, err := client.Vehicles.GetChargingLocation(selectedVin, chargingId)
-		if err != nil {
-			return err
-		}
-		dc.StartTime = cl.DelayCharging.StartTime
-		dc.StopTime = cl.DelayCharging.StopTime
+		chargingId = c.Args().First() // SetDelayCharging keeps the current start/stop times
 	case 2:
 		return fmt.Errorf("unexpected number of arguments were passed. minimum 1 or exactly 3 allowed")
 	case 3:
 		chargingId = c.Args().First()
-		startTime = c.Args().Get(1)
-		stopTime = c.Args().Get(2)
-		dc.StartTime = startTime
-		dc.StopTime = stopTime
+		window, err := parseClockWindow(c.Args().Get(1), c.Args().Get(2))
+		if err != nil {
+			return err
+		}
+		dc.StartTime = window.Start
+		dc.StopTime = window.Stop
 	default:
 		return fmt.Errorf("unexpected number of arguments were passed. minimum 1 or exactly 3 allowed")
 	}
 
-	vehicle, err := client.Vehicles.GetVehicleByVIN(selectedVin)
+	vehicle, err := client.Vehicles.GetVehicleByVIN(c.Context, selectedVin)
 	if err != nil {
 		return err
 	}
 
-	if _, err = vehicle.SetDelayCharging(chargingId, &dc); err != nil {
+	if _, err = vehicle.SetDelayCharging(c.Context, chargingId, &dc); err != nil {
 		return err
 	}
 	return nil
@@ -417,44 +828,78 @@ func actionDisableDelayCharging(c *cli.Context) error {
 	dc := vocdriver.DelayCharging{
 		Enabled: false,
 	}
-	vehicle, err := client.Vehicles.GetVehicleByVIN(selectedVin)
+	vehicle, err := client.Vehicles.GetVehicleByVIN(c.Context, selectedVin)
 	if err != nil {
 		return err
 	}
-	if _, err = vehicle.SetDelayCharging(c.Args().First(), &dc); err != nil {
+	if _, err = vehicle.SetDelayCharging(c.Context, c.Args().First(), &dc); err != nil {
 		return err
 	}
 	return nil
 }
 
 func actionUpdateDelayCharging(c *cli.Context) error {
-	var (
-		chargingId, startTime, stopTime string
-	)
+	var chargingId string
 	dc := vocdriver.DelayCharging{}
 	switch c.Args().Len() {
 	case 3:
 		chargingId = c.Args().First()
-		startTime = c.Args().Get(1)
-		stopTime = c.Args().Get(2)
+		window, err := parseClockWindow(c.Args().Get(1), c.Args().Get(2))
+		if err != nil {
+			return err
+		}
 
-		cl, err := client.Vehicles.GetChargingLocation(selectedVin, chargingId)
+		cl, err := client.Vehicles.GetChargingLocation(c.Context, selectedVin, chargingId)
 		if err != nil {
 			return err
 		}
-		dc.Enabled = cl.DelayCharging.Enabled // keep current status
-		dc.StartTime = startTime
-		dc.StopTime = stopTime
+		dc.Enabled = cl.DelayCharging != nil && cl.DelayCharging.Enabled // keep current status
+		dc.StartTime = window.Start
+		dc.StopTime = window.Stop
 	default:
 		return fmt.Errorf("you must provide: charging location id + start time + stop time. see --help for more details")
 	}
 
-	vehicle, err := client.Vehicles.GetVehicleByVIN(selectedVin)
+	vehicle, err := client.Vehicles.GetVehicleByVIN(c.Context, selectedVin)
 	if err != nil {
 		return err
 	}
 
-	if _, err = vehicle.SetDelayCharging(chargingId, &dc); err != nil {
+	if _, err = vehicle.SetDelayCharging(c.Context, chargingId, &dc); err != nil {
+		return err
+	}
+	return nil
+}
+
+func actionSimulate(c *cli.Context) error {
+	handler := voctest.NewHandler(nil)
+	credentials := fmt.Sprintf("%s / %s", voctest.Username, voctest.Password)
+	if simulateScenario != "" {
+		scenario, err := voctest.LoadScenario(simulateScenario)
+		if err != nil {
+			return err
+		}
+		handler.SetScenario(scenario)
+		if scenario.Username != "" {
+			credentials = fmt.Sprintf("%s / %s", scenario.Username, scenario.Password)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	srv := &http.Server{Addr: simulateAddr, Handler: handler}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Printf("Simulated Volvo On Call API listening on http://%s\n", simulateAddr)
+	fmt.Printf("  Credentials: %s\n", credentials)
+	fmt.Printf("  Car: %s\n", voctest.VIN)
+	fmt.Println("Press Ctrl+C to stop")
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		return err
 	}
 	return nil
@@ -8,13 +8,15 @@ import (
 )
 
 type Configuration struct {
-	Username string
-	Password string
-	Region   string
-	URL      string
-	MyCarVIN string
+	Username        string
+	Password        string
+	PasswordCommand string // command printing the password, e.g.: pass show volvo
+	Region          string
+	URL             string
+	MyCarVIN        string
 }
 
+// LoadFromFile loads the configuration file at path. Values already set (e.g.: via CLI flags) take precedence
 func (c *Configuration) LoadFromFile(path string) (err error) {
 	file, err := os.Open(path)
 	if err != nil {
@@ -35,15 +37,17 @@ func (c *Configuration) LoadFromFile(path string) (err error) {
 		tuple := strings.Split(line, ": ")
 		switch {
 		case tuple[0] == "username":
-			c.Username = tuple[1]
+			setIfEmpty(&c.Username, tuple[1])
 		case tuple[0] == "password":
-			c.Password = tuple[1]
+			setIfEmpty(&c.Password, tuple[1])
+		case tuple[0] == "passwordCommand":
+			setIfEmpty(&c.PasswordCommand, tuple[1])
 		case tuple[0] == "region":
-			c.Region = tuple[1]
+			setIfEmpty(&c.Region, tuple[1])
 		case tuple[0] == "url":
-			c.URL = tuple[1]
+			setIfEmpty(&c.URL, tuple[1])
 		case tuple[0] == "defaultCarVin":
-			c.MyCarVIN = strings.TrimSpace(tuple[1])
+			setIfEmpty(&c.MyCarVIN, strings.TrimSpace(tuple[1]))
 		default:
 			fmt.Println("invalid case:", tuple[1])
 		}
@@ -57,7 +61,13 @@ func (c *Configuration) WriteToFile(path string) (err error) {
 		return err
 	}
 	defer f.Close()
-	s := fmt.Sprintf("username: %s\npassword: %s\n", c.Username, c.Password)
+	s := fmt.Sprintf("username: %s\n", c.Username)
+	if c.Password != "" {
+		s = s + fmt.Sprintf("password: %s\n", c.Password)
+	}
+	if c.PasswordCommand != "" {
+		s = s + fmt.Sprintf("passwordCommand: %s\n", c.PasswordCommand)
+	}
 	if c.Region != "" {
 		s = s + fmt.Sprintf("region: %s\n", c.Region)
 	}
@@ -69,3 +79,9 @@ func (c *Configuration) WriteToFile(path string) (err error) {
 	}
 	return nil
 }
+
+func setIfEmpty(field *string, value string) {
+	if *field == "" {
+		*field = value
+	}
+}
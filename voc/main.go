@@ -4,8 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
 	vocdriver "github.com/theriverman/VolvoOnCall"
 	"github.com/urfave/cli/v2"
@@ -27,11 +29,31 @@ var client *vocdriver.Client
 
 // application behaviour
 var appVerboseMode bool = false
+var recordPath string = ""
+var replayPath string = ""
+var recordFile *os.File
 
 // runtime values
 var selectedVin string = ""
 var asJson bool = false
 var customAttributes *cli.StringSlice = &cli.StringSlice{}
+var simulateAddr string = ""
+var simulateScenario string = ""
+var engineRuntime int = vocdriver.MaxEngineRuntime
+var engineAutoStop time.Duration = 0
+var poi vocdriver.POI = vocdriver.POI{}
+var chargingLocationName string = ""
+var chargingLocationPosition vocdriver.ChargingLocationPosition = vocdriver.ChargingLocationPosition{}
+var tripsQuery vocdriver.TripsQuery = vocdriver.TripsQuery{}
+var tripsSince string = ""
+var tripsUntil string = ""
+var tripsCategory string = ""
+var tripName string = ""
+var tripCategory string = ""
+var tripNotes string = ""
+
+// passwordCommandCacheTTL avoids running --password-command before each request of a single invocation
+const passwordCommandCacheTTL = 5 * time.Minute
 
 // NewApplication is the primary entrypoint to our CLI application. the base logic shall be implemented here
 func NewApplication() *cli.App {
@@ -55,6 +77,31 @@ func NewApplication() *cli.App {
 				Destination: &Config.Password,
 				Usage:       "Volvo On Call password",
 			},
+			&cli.StringFlag{
+				Name:        "password-command",
+				Destination: &Config.PasswordCommand,
+				Usage:       "Command printing your Volvo On Call password (e.g.: \"pass show volvo\")",
+			},
+			&cli.StringFlag{
+				Name:        "region",
+				Destination: &Config.Region,
+				Usage:       "Volvo On Call service region (e.g.: na, cn, \"North America\" or auto to detect it)",
+			},
+			&cli.StringFlag{
+				Name:        "url",
+				Destination: &Config.URL,
+				Usage:       "Custom Volvo On Call API URL (e.g.: a local voctest server)",
+			},
+			&cli.StringFlag{
+				Name:        "record",
+				Destination: &recordPath,
+				Usage:       "Record every API request/response to a JSONL file (credentials and VINs are redacted)",
+			},
+			&cli.StringFlag{
+				Name:        "replay",
+				Destination: &replayPath,
+				Usage:       "Replay API responses from a JSONL file created by --record instead of contacting Volvo On Call",
+			},
 		},
 		Before: func(c *cli.Context) error {
 			// CLI flags are processed at this point. Consider configuring your logging level
@@ -72,18 +119,75 @@ func NewApplication() *cli.App {
 					// add logging here
 					return err
 				}
-			} else if errors.Is(err, os.ErrNotExist) {
-				// add verbose logging here stating that the default config file does not exist
-				fmt.Println("$HOME/.voc.conf was not found")
+			} else if errors.Is(err, os.ErrNotExist) && appVerboseMode {
+				fmt.Fprintln(os.Stderr, "$HOME/.voc.conf was not found")
+			}
+			opts := []vocdriver.Option{vocdriver.WithRetryPolicy(vocdriver.DefaultRetryPolicy())}
+			// a custom URL already points to a region, so it wins over the configured one
+			switch {
+			case Config.URL != "":
+				opts = append(opts, vocdriver.WithBaseURL(Config.URL))
+			case Config.Region != "":
+				region, err := vocdriver.ParseRegion(Config.Region)
+				if err != nil {
+					return err
+				}
+				opts = append(opts, vocdriver.WithRegion(region))
 			}
-			client = &vocdriver.Client{
-				ServiceRegion: Config.Region,
-				BaseURL:       Config.URL,
+			// a password command given on the command line wins over a password stored in the configuration file
+			if c.IsSet("password-command") && !c.IsSet("password") {
+				Config.Password = ""
 			}
-			if err = client.Initialise(); err != nil {
+			// credentials: --password > --password-command > $VOC_USERNAME/$VOC_PASSWORD
+			switch {
+			case Config.Password != "":
+				opts = append(opts, vocdriver.WithCredentials(Config.Username, Config.Password))
+			case Config.PasswordCommand != "":
+				username := Config.Username
+				if username == "" {
+					username = os.Getenv(vocdriver.EnvUsername)
+				}
+				provider, err := vocdriver.NewCommandCredentials(username, Config.PasswordCommand, passwordCommandCacheTTL)
+				if err != nil {
+					return err
+				}
+				opts = append(opts, vocdriver.WithCredentialsProvider(provider))
+			case os.Getenv(vocdriver.EnvUsername) != "":
+				opts = append(opts, vocdriver.WithCredentialsProvider(vocdriver.EnvCredentials{}))
+			}
+			if appVerboseMode {
+				// logs requests, responses and service status polls to stderr
+				opts = append(opts, vocdriver.WithLogger(vocdriver.NewStdLogger(os.Stderr, vocdriver.LevelDebug)))
+			}
+			switch {
+			case recordPath != "" && replayPath != "":
+				return fmt.Errorf("--record and --replay cannot be used together")
+			case recordPath != "":
+				if recordFile, err = os.Create(recordPath); err != nil {
+					return err
+				}
+				opts = append(opts, vocdriver.WithHTTPClient(&http.Client{Transport: vocdriver.NewRecordingTransport(recordFile, nil)}))
+			case replayPath != "":
+				f, err := os.Open(replayPath)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				replay, err := vocdriver.NewReplayTransport(f)
+				if err != nil {
+					return err
+				}
+				opts = append(opts, vocdriver.WithHTTPClient(&http.Client{Transport: replay}))
+			}
+			if client, err = vocdriver.NewClientContext(c.Context, opts...); err != nil {
 				return err
 			}
-			client.Authenticate(Config.Username, Config.Password)
+			return nil
+		},
+		After: func(c *cli.Context) error {
+			if recordFile != nil {
+				return recordFile.Close()
+			}
 			return nil
 		},
 		Commands: []*cli.Command{
@@ -154,6 +258,35 @@ func NewApplication() *cli.App {
 						Usage:  "Stop  the car's heater",
 						Action: actionStopHeater,
 					},
+					{
+						Name:  "timer",
+						Usage: "View/Change the daily timers of the car's remote heater",
+						Subcommands: []*cli.Command{
+							{
+								Name:   "list",
+								Usage:  "List the heater timers and the heated seats",
+								Action: actionListHeaterTimers,
+							},
+							{
+								Name:      "set",
+								Usage:     "Set the time of a heater timer",
+								Action:    actionSetHeaterTimer,
+								UsageText: "Pass the timer's number (1 or 2) after the `set` command along with a time. The timer keeps its enabled state\nFor example: voc heater timer set 1 06:45",
+							},
+							{
+								Name:      "enable",
+								Usage:     "Enable a heater timer",
+								Action:    actionEnableHeaterTimer,
+								UsageText: "Pass the timer's number (1 or 2) after the `enable` command\nFor example: voc heater timer enable 1",
+							},
+							{
+								Name:      "disable",
+								Usage:     "Disable a heater timer",
+								Action:    actionDisableHeaterTimer,
+								UsageText: "Pass the timer's number (1 or 2) after the `disable` command\nFor example: voc heater timer disable 1",
+							},
+						},
+					},
 				},
 			},
 
@@ -165,8 +298,21 @@ func NewApplication() *cli.App {
 				Before: selectVinOrThrowError,
 				Subcommands: []*cli.Command{
 					{
-						Name:   "start",
-						Usage:  "Start the car's engine",
+						Name:  "start",
+						Usage: "Start the car's engine",
+						Flags: []cli.Flag{
+							&cli.IntFlag{
+								Name:        "runtime",
+								Usage:       fmt.Sprintf("Minutes (%d-%d) after which the car stops the engine by itself", vocdriver.MinEngineRuntime, vocdriver.MaxEngineRuntime),
+								Value:       vocdriver.MaxEngineRuntime,
+								Destination: &engineRuntime,
+							},
+							&cli.DurationFlag{
+								Name:        "auto-stop",
+								Usage:       "Keep running and stop the engine after this duration (e.g.: 5m) or when interrupted with Ctrl+C",
+								Destination: &engineAutoStop,
+							},
+						},
 						Action: actionStartEngine,
 					},
 					{
@@ -197,6 +343,57 @@ func NewApplication() *cli.App {
 				},
 			},
 
+			// climatization calendar
+			{
+				Name:   "climate",
+				Usage:  "View/Change the climatization calendar",
+				Flags:  commonFlagsVin(),
+				Before: selectVinOrThrowError,
+				Subcommands: []*cli.Command{
+					{
+						Name:  "calendar",
+						Usage: "Manage the recurring departure timers preconditioning the car",
+						Subcommands: []*cli.Command{
+							{
+								Name:   "list",
+								Usage:  "List the departure timers",
+								Action: actionListClimatizationTimers,
+							},
+							{
+								Name:      "add",
+								Usage:     "Add an enabled departure timer",
+								Action:    actionAddClimatizationTimer,
+								UsageText: "Pass the days (e.g.: mon,wed,fri or workdays, weekend, everyday) after the `add` command along with a departure time\nFor example: voc climate calendar add workdays 07:30",
+							},
+							{
+								Name:      "update",
+								Usage:     "Update the days and the departure time of a timer",
+								Action:    actionUpdateClimatizationTimer,
+								UsageText: "Pass a timer's ID after the `update` command along with the days and a departure time. The timer keeps its enabled state\nFor example: voc climate calendar update 1 mon,tue 06:45",
+							},
+							{
+								Name:      "enable",
+								Usage:     "Enable a departure timer",
+								Action:    actionEnableClimatizationTimer,
+								UsageText: "Pass a timer's ID after the `enable` command\nFor example: voc climate calendar enable 1",
+							},
+							{
+								Name:      "disable",
+								Usage:     "Disable a departure timer",
+								Action:    actionDisableClimatizationTimer,
+								UsageText: "Pass a timer's ID after the `disable` command\nFor example: voc climate calendar disable 1",
+							},
+							{
+								Name:      "remove",
+								Usage:     "Remove a departure timer",
+								Action:    actionRemoveClimatizationTimer,
+								UsageText: "Pass a timer's ID after the `remove` command\nFor example: voc climate calendar remove 1",
+							},
+						},
+					},
+				},
+			},
+
 			// honk and blink
 			{
 				Name:   "blink",
@@ -214,6 +411,22 @@ func NewApplication() *cli.App {
 				Action: actionHonk,
 			},
 
+			// points of interest
+			{
+				Name:   "send-poi",
+				Usage:  "Send a point of interest (e.g.: a destination) to the car's navigation system",
+				Before: selectVinOrThrowError,
+				Action: actionSendPOI,
+				Flags: append(commonFlagsVin(), append([]cli.Flag{
+					&cli.StringFlag{
+						Name:        "name",
+						Usage:       "Name shown in the car",
+						Required:    true,
+						Destination: &poi.Name,
+					},
+				}, positionFlags(&poi.Position)...)...),
+			},
+
 			// position
 			{
 				Name:   "position",
@@ -254,7 +467,7 @@ func NewApplication() *cli.App {
 			// trips
 			{
 				Name:   "trips",
-				Usage:  "Print a brief overview about the last trips",
+				Usage:  "Print a brief overview about the last trips, edit or delete them",
 				Action: actionTrips,
 				Before: selectVinOrThrowError,
 				Flags: append(commonFlagsVin(), []cli.Flag{
@@ -264,7 +477,63 @@ func NewApplication() *cli.App {
 						Value:       false,
 						Destination: &asJson,
 					},
+					&cli.StringFlag{
+						Name:        "since",
+						Usage:       "Only trips started at or after this date, e.g.: 2022-11-01 or 2022-11-01T08:00:00+0100",
+						Destination: &tripsSince,
+					},
+					&cli.StringFlag{
+						Name:        "until",
+						Usage:       "Only trips started before this date, e.g.: 2022-12-01",
+						Destination: &tripsUntil,
+					},
+					&cli.StringFlag{
+						Name:        "category",
+						Usage:       "Only trips of this category, e.g.: business",
+						Destination: &tripsCategory,
+					},
+					&cli.IntFlag{
+						Name:        "limit",
+						Usage:       "Maximum number of trips returned",
+						Destination: &tripsQuery.Limit,
+					},
+					&cli.IntFlag{
+						Name:        "offset",
+						Usage:       "Number of matching trips skipped (for paging together with --limit)",
+						Destination: &tripsQuery.Offset,
+					},
 				}...),
+				Subcommands: []*cli.Command{
+					{
+						Name:      "edit",
+						Usage:     "Change the name, the category or the notes of a trip in the journal log",
+						Action:    actionEditTrip,
+						UsageText: "Pass a trip's ID after the `edit` command along with the fields to change\nFor example: voc trips edit 1001 --category business --notes \"client visit\"",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:        "name",
+								Usage:       "Name of the trip",
+								Destination: &tripName,
+							},
+							&cli.StringFlag{
+								Name:        "category",
+								Usage:       "Category of the trip: business, private or commute",
+								Destination: &tripCategory,
+							},
+							&cli.StringFlag{
+								Name:        "notes",
+								Usage:       "Notes of the trip, e.g.: the purpose of a business trip",
+								Destination: &tripNotes,
+							},
+						},
+					},
+					{
+						Name:      "delete",
+						Usage:     "Delete a trip from the journal log",
+						Action:    actionDeleteTrip,
+						UsageText: "Pass a trip's ID after the `delete` command\nFor example: voc trips delete 1001",
+					},
+				},
 			},
 			// owntracks
 
@@ -290,7 +559,12 @@ func NewApplication() *cli.App {
 						Usage:       "Your Volvo On Call password",
 						Value:       Config.Password,
 						Destination: &Config.Password,
-						Required:    true,
+					},
+					&cli.StringFlag{
+						Name:        "password-command",
+						Usage:       "Command printing your Volvo On Call password instead of storing it (e.g.: \"pass show volvo\")",
+						Value:       Config.PasswordCommand,
+						Destination: &Config.PasswordCommand,
 					},
 				},
 			},
@@ -312,6 +586,54 @@ func NewApplication() *cli.App {
 						Action:    actionGetChargingLocationById,
 						UsageText: "Pass a charging location's ID to get its details. Use the list command to find charging locations",
 					},
+					{
+						Name:   "override",
+						Usage:  "Start charging now, ignoring the delay charging window of the current location",
+						Action: actionOverrideDelayCharging,
+					},
+					{
+						Name:   "add",
+						Usage:  "Add a charging location",
+						Action: actionAddChargingLocation,
+						Flags: append([]cli.Flag{
+							&cli.StringFlag{
+								Name:        "name",
+								Usage:       "Name of the charging location",
+								Required:    true,
+								Destination: &chargingLocationName,
+							},
+						}, positionFlags(&chargingLocationPosition)...),
+					},
+					{
+						Name:      "rename",
+						Usage:     "Rename a charging location",
+						Action:    actionRenameChargingLocation,
+						UsageText: "Pass a charging location's ID after the `rename` command along with the new name\nFor example: voc charging rename 4075649 Home",
+					},
+					{
+						Name:      "remove",
+						Usage:     "Remove a charging location",
+						Action:    actionRemoveChargingLocation,
+						UsageText: "Pass a charging location's ID after the `remove` command\nFor example: voc charging remove 4075649",
+					},
+					{
+						Name:  "reminder",
+						Usage: "Enable/Disable the reminder to plug in the car at a charging location",
+						Subcommands: []*cli.Command{
+							{
+								Name:      "enable",
+								Usage:     "Enable the plug in reminder for a charging location",
+								Action:    actionEnablePlugInReminder,
+								UsageText: "Pass a charging location's ID after the `enable` command\nFor example: voc charging reminder enable 4075649",
+							},
+							{
+								Name:      "disable",
+								Usage:     "Disable the plug in reminder for a charging location",
+								Action:    actionDisablePlugInReminder,
+								UsageText: "Pass a charging location's ID after the `disable` command\nFor example: voc charging reminder disable 4075649",
+							},
+						},
+					},
 					{
 						Name:  "delay",
 						Usage: "Enable/Disable delay charging and optionally update start/stop times",
@@ -339,6 +661,26 @@ func NewApplication() *cli.App {
 				},
 			},
 
+			// simulator
+			{
+				Name:   "simulate",
+				Usage:  "Serve a simulated Volvo On Call API with a stateful car for testing",
+				Action: actionSimulate,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:        "addr",
+						Usage:       "Address to listen on",
+						Value:       "127.0.0.1:8080",
+						Destination: &simulateAddr,
+					},
+					&cli.StringFlag{
+						Name:        "scenario",
+						Usage:       "Path to a JSON scenario file (delays, failure injection, offline car)",
+						Destination: &simulateScenario,
+					},
+				},
+			},
+
 			// VOC version
 			{
 				Name:   "version",
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+	"github.com/theriverman/VolvoOnCall/voctest"
+)
+
+// runVoc runs the voc application with args against srv as a user without $HOME/.voc.conf and returns what it printed
+// to stdout
+func runVoc(t *testing.T, srv *voctest.Server, args ...string) (string, error) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	global := []string{"voc", "--url", srv.URL, "--username", voctest.Username, "--password", voctest.Password}
+	return runApp(t, append(global, args...)...)
+}
+
+// runApp runs the voc application with the complete command line args and returns what it printed to stdout
+func runApp(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	t.Setenv("VOC_USERNAME", "")
+	t.Setenv("VOC_PASSWORD", "")
+	// the flags bind package level variables, which must not leak between runs
+	Config = Configuration{}
+	selectedVin = ""
+	asJson = false
+	client = nil
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+	output := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		_, _ = io.Copy(&buf, r)
+		output <- buf.String()
+	}()
+
+	err = NewApplication().Run(args)
+	w.Close()
+	return <-output, err
+}
+
+func newTestServer(t *testing.T) *voctest.Server {
+	t.Helper()
+	srv := voctest.NewServer(nil)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestCars(t *testing.T) {
+	srv := newTestServer(t)
+	out, err := runVoc(t, srv, "cars")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, voctest.VIN) {
+		t.Errorf("expected %s to be listed, got:\n%s", voctest.VIN, out)
+	}
+}
+
+func TestAttributes(t *testing.T) {
+	srv := newTestServer(t)
+	out, err := runVoc(t, srv, "attributes", "--vin", voctest.VIN, "--json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, `"registrationNumber": "ABC123"`) {
+		t.Errorf("expected the attributes of the car, got:\n%s", out)
+	}
+}
+
+func TestUnlock(t *testing.T) {
+	srv := newTestServer(t)
+	out, err := runVoc(t, srv, "unlock", "--vin", voctest.VIN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "Successful") {
+		t.Errorf("expected the unlock to succeed, got:\n%s", out)
+	}
+	if v, _ := srv.Handler.Vehicle(voctest.VIN); v.Status.CarLocked {
+		t.Error("expected the car to be unlocked")
+	}
+}
+
+func TestMissingVin(t *testing.T) {
+	srv := newTestServer(t)
+	if _, err := runVoc(t, srv, "status"); err == nil {
+		t.Fatal("expected an error without --vin")
+	}
+}
+
+func TestEditTrip(t *testing.T) {
+	srv := newTestServer(t)
+	out, err := runVoc(t, srv, "trips", "--vin", voctest.VIN, "edit", "1001", "--category", "business", "--notes", "client visit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "Category: business") || !strings.Contains(out, "User Notes: client visit") {
+		t.Errorf("expected the updated trip, got:\n%s", out)
+	}
+	v, _ := srv.Handler.Vehicle(voctest.VIN)
+	for _, trip := range v.Trips {
+		if trip.ID == 1001 && (trip.Category != vocdriver.TripCategoryBusiness || trip.UserNotes != "client visit") {
+			t.Errorf("expected trip 1001 to be updated, got: %+v", trip)
+		}
+	}
+}
+
+func TestEditTrip_FlagsBeforeID(t *testing.T) {
+	srv := newTestServer(t)
+	if _, err := runVoc(t, srv, "trips", "--vin", voctest.VIN, "edit", "--name", "Office", "1001", "--notes", "client visit"); err != nil {
+		t.Fatal(err)
+	}
+	v, _ := srv.Handler.Vehicle(voctest.VIN)
+	for _, trip := range v.Trips {
+		if trip.ID == 1001 && (trip.Name != "Office" || trip.UserNotes != "client visit") {
+			t.Errorf("expected trip 1001 to be updated, got: %+v", trip)
+		}
+	}
+}
+
+func TestEditTrip_UnknownFlag(t *testing.T) {
+	srv := newTestServer(t)
+	if _, err := runVoc(t, srv, "trips", "--vin", voctest.VIN, "edit", "1001", "--colour", "red"); err == nil {
+		t.Fatal("expected an error for an unknown flag")
+	}
+}
+
+func TestPasswordCommandOverridesConfigPassword(t *testing.T) {
+	srv := newTestServer(t)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	conf := "username: " + voctest.Username + "\npassword: outdated-password\n"
+	if err := os.WriteFile(filepath.Join(home, ".voc.conf"), []byte(conf), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runApp(t, "voc", "--url", srv.URL, "cars"); err == nil {
+		t.Fatal("expected the outdated password of the configuration file to be rejected")
+	}
+	if _, err := runApp(t, "voc", "--url", srv.URL, "--password-command", "echo "+voctest.Password, "cars"); err != nil {
+		t.Fatalf("expected --password-command to win over the configuration file: %v", err)
+	}
+}
+
+func TestSendPOI(t *testing.T) {
+	srv := newTestServer(t)
+	if _, err := runVoc(t, srv, "send-poi", "--vin", voctest.VIN, "--name", "Greenwich", "--lat", "51.4779", "--lon", "0", "--city", "London"); err != nil {
+		t.Fatal(err)
+	}
+	want := vocdriver.POI{Name: "Greenwich", Position: vocdriver.ChargingLocationPosition{Latitude: 51.4779, City: "London"}}
+	if v, _ := srv.Handler.Vehicle(voctest.VIN); len(v.POIs) != 1 || v.POIs[0] != want {
+		t.Errorf("unexpected POIs received by the car: %+v", v.POIs)
+	}
+}
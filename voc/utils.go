@@ -2,10 +2,15 @@ package main
 
 import (
 	"bufio"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"time"
 
+	vocdriver "github.com/theriverman/VolvoOnCall"
 	"github.com/urfave/cli/v2"
 )
 
@@ -43,3 +48,121 @@ func commonFlagsVin() []cli.Flag {
 		},
 	}
 }
+
+// evaluateServiceStatus waits for the remote service behind status to finish and reports its outcome
+func evaluateServiceStatus(c *cli.Context, status *vocdriver.VehicleServiceStatus) error {
+	if err := client.Vehicles.EvaluateServiceStatusAuto(c.Context, status); err != nil {
+		return err
+	}
+	fmt.Printf("%s: %s\n", status.ServiceType, status.Status)
+	return nil
+}
+
+// waitForOperation waits for op to finish and reports its outcome like evaluateServiceStatus
+func waitForOperation(c *cli.Context, op *vocdriver.Operation) error {
+	if err := op.Wait(c.Context); err != nil {
+		return err
+	}
+	status := op.Status()
+	fmt.Printf("%s: %s\n", status.ServiceType, status.Status)
+	return nil
+}
+
+// trailingFlag forwards a flag found after the positional arguments to the flag set of the command
+type trailingFlag struct {
+	c      *cli.Context
+	name   string
+	isBool bool
+}
+
+func (f trailingFlag) String() string     { return "" }
+func (f trailingFlag) Set(s string) error { return f.c.Set(f.name, s) }
+func (f trailingFlag) IsBoolFlag() bool   { return f.isBool }
+
+// parseTrailingFlags parses the flags of the command following its positional arguments (urfave/cli stops at the first one),
+// e.g.: the `--category business` of `voc trips edit 1001 --category business`. values end up where the flags would have put them
+func parseTrailingFlags(c *cli.Context, args []string) error {
+	fs := flag.NewFlagSet(c.Command.Name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	for _, f := range c.Command.Flags {
+		if f == cli.HelpFlag {
+			continue // left to the flag package, which reports flag.ErrHelp
+		}
+		_, isBool := f.(*cli.BoolFlag)
+		for _, name := range f.Names() {
+			fs.Var(trailingFlag{c: c, name: name, isBool: isBool}, name, "")
+		}
+	}
+	if err := fs.Parse(args); errors.Is(err, flag.ErrHelp) {
+		return cli.ShowSubcommandHelp(c)
+	} else if err != nil {
+		return fmt.Errorf("%w. see --help for more details", err)
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %v. see --help for more details", fs.Args())
+	}
+	return nil
+}
+
+// parseClockWindow parses the start and the stop time of a window passed as arguments, e.g.: 22:00 06:00
+func parseClockWindow(start, stop string) (vocdriver.ClockWindow, error) {
+	startTime, err := vocdriver.ParseClockTime(start)
+	if err != nil {
+		return vocdriver.ClockWindow{}, fmt.Errorf("invalid start time: %w", err)
+	}
+	stopTime, err := vocdriver.ParseClockTime(stop)
+	if err != nil {
+		return vocdriver.ClockWindow{}, fmt.Errorf("invalid stop time: %w", err)
+	}
+	return vocdriver.ClockWindow{Start: startTime, Stop: stopTime}, nil
+}
+
+// parseDate parses a date (e.g.: 2022-11-01) as midnight in the local time zone, or a timestamp in any of the formats of the VOC API
+func parseDate(s string) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	ts, err := vocdriver.ParseTimestamp(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ts.Time, nil
+}
+
+// positionFlags bind the coordinates (required) and the address (optional) of a location to p
+func positionFlags(p *vocdriver.ChargingLocationPosition) []cli.Flag {
+	return []cli.Flag{
+		&cli.Float64Flag{
+			Name:        "lat",
+			Usage:       "Latitude of the location",
+			Required:    true,
+			Destination: &p.Latitude,
+		},
+		&cli.Float64Flag{
+			Name:        "lon",
+			Usage:       "Longitude of the location",
+			Required:    true,
+			Destination: &p.Longitude,
+		},
+		&cli.StringFlag{
+			Name:        "street",
+			Usage:       "Street address (optional)",
+			Destination: &p.StreetAddress,
+		},
+		&cli.StringFlag{
+			Name:        "postal-code",
+			Usage:       "Postal code (optional)",
+			Destination: &p.PostalCode,
+		},
+		&cli.StringFlag{
+			Name:        "city",
+			Usage:       "City (optional)",
+			Destination: &p.City,
+		},
+		&cli.StringFlag{
+			Name:        "country",
+			Usage:       "ISO 3166-1 alpha-2 country code (optional)",
+			Destination: &p.ISO2CountryCode,
+		},
+	}
+}
@@ -0,0 +1,215 @@
+package voctest
+
+import (
+	"time"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+)
+
+// Default credentials and identifiers used by DefaultFixtures
+const (
+	Username  = "john.doe@example.com"
+	Password  = "voctest-password"
+	AccountID = "c0ffee00-1234-5678-9abc-def012345678"
+	VIN       = "YV1ABCDEFGH123456"
+)
+
+// Fixtures seed the data served by a Handler
+type Fixtures struct {
+	Username string // accepted Basic auth username. Authentication is not checked if empty
+	Password string // accepted Basic auth password
+	Account  Account
+	Vehicles []Vehicle
+
+	// ServiceStatus is the terminal status of remote commands without a failure injected by the Scenario (default: Successful)
+	ServiceStatus vocdriver.ServiceStatus
+	// FailureReason is reported along with ServiceStatus
+	FailureReason string
+}
+
+// Account is served at /customeraccounts
+type Account struct {
+	Username  string
+	FirstName string
+	LastName  string
+	AccountID string
+}
+
+// Vehicle is a car associated with the Account
+type Vehicle struct {
+	VIN                   string
+	RelationID            int // customerVehicleRelationId; assigned automatically if 0
+	Attributes            vocdriver.VehicleAttributes
+	Status                vocdriver.VehicleStatus
+	Position              vocdriver.VehiclePosition
+	Trips                 []vocdriver.Trip
+	ChargingLocations     []vocdriver.ChargingLocation // the ID of a location is path.Base(ChargeLocation); assigned automatically if empty
+	ClimatizationCalendar vocdriver.ClimatizationCalendar
+	POIs                  []vocdriver.POI // points of interest successfully sent to the car
+}
+
+// DefaultFixtures returns an account with a single plug-in hybrid supporting every remote service
+func DefaultFixtures() *Fixtures {
+	return &Fixtures{
+		Username: Username,
+		Password: Password,
+		Account: Account{
+			Username:  Username,
+			FirstName: "John",
+			LastName:  "Doe",
+			AccountID: AccountID,
+		},
+		Vehicles:      []Vehicle{DefaultVehicle()},
+		ServiceStatus: vocdriver.ServiceStatusSuccessful,
+	}
+}
+
+// DefaultVehicle returns an XC60 T8 parked in Stockholm identified by VIN
+func DefaultVehicle() Vehicle {
+	timestamp := vocdriver.NewTimestamp(time.Date(2022, 11, 20, 8, 15, 0, 0, time.UTC))
+	v := Vehicle{
+		VIN:        VIN,
+		RelationID: 1,
+		Attributes: vocdriver.VehicleAttributes{
+			EngineCode:                             "B4204T35",
+			ExteriorCode:                           "707",
+			InteriorCode:                           "RA00",
+			GearboxCode:                            "GAT8",
+			FuelType:                               "Petrol",
+			FuelTankVolume:                         70,
+			GrossWeight:                            2650,
+			ModelYear:                              2021,
+			VehicleType:                            "XC60 T8 Twin Engine",
+			VehicleTypeCode:                        "246",
+			NumberOfDoors:                          5,
+			RegistrationNumber:                     "ABC123",
+			CarLocatorDistance:                     2000,
+			HonkAndBlinkDistance:                   1000,
+			CarLocatorSupported:                    true,
+			HonkAndBlinkSupported:                  true,
+			HonkAndBlinkVersionsSupported:          []string{"honkAndOrBlink"},
+			RemoteHeaterSupported:                  true,
+			UnlockSupported:                        true,
+			LockSupported:                          true,
+			JournalLogSupported:                    true,
+			AssistanceCallSupported:                true,
+			UnlockTimeFrame:                        120,
+			VerificationTimeFrame:                  120,
+			TimeFullyAccessible:                    15,
+			TimePartiallyAccessible:                120,
+			SubscriptionType:                       "VOC",
+			SubscriptionStartDate:                  vocdriver.NewTimestamp(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			SubscriptionEndDate:                    vocdriver.NewTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			ServerVersion:                          "voctest",
+			Vin:                                    VIN,
+			JournalLogEnabled:                      true,
+			HighVoltageBatterySupported:            true,
+			MaxActiveDelayChargingLocations:        1,
+			PreclimatizationSupported:              true,
+			SendPOIToVehicleVersionsSupported:      []string{"1"},
+			ClimatizationCalendarVersionsSupported: []string{"1"},
+			ClimatizationCalendarMaxTimers:         8,
+			VehiclePlatform:                        "SPA",
+			OverrideDelayChargingSupported:         true,
+			EngineStartSupported:                   true,
+		},
+		Position: vocdriver.VehiclePosition{
+			Position: vocdriver.Position{
+				Longitude: 18.068581,
+				Latitude:  59.329323,
+				Timestamp: timestamp,
+			},
+		},
+		Trips: []vocdriver.Trip{
+			{
+				ID:       1001,
+				Category: "private",
+				TripDetails: []vocdriver.TripDetail{{
+					FuelConsumption:        120,
+					ElectricalConsumption:  4200,
+					ElectricalRegeneration: 800,
+					Distance:               24500,
+					StartOdometer:          42170000,
+					StartTime:              vocdriver.NewTimestamp(time.Date(2022, 11, 19, 16, 2, 0, 0, time.UTC)),
+					StartPosition:          vocdriver.TripPosition{Longitude: 17.945, Latitude: 59.404, City: "Kista", ISO2CountryCode: "SE"},
+					EndOdometer:            42194500,
+					EndTime:                vocdriver.NewTimestamp(time.Date(2022, 11, 19, 16, 31, 0, 0, time.UTC)),
+					EndPosition:            vocdriver.TripPosition{Longitude: 18.068581, Latitude: 59.329323, City: "Stockholm", ISO2CountryCode: "SE"},
+				}},
+			},
+		},
+		ChargingLocations: []vocdriver.ChargingLocation{
+			{
+				ChargeLocation:        "4075649",
+				Name:                  "Home",
+				PlugInReminderEnabled: true,
+				Position: &vocdriver.ChargingLocationPosition{
+					Longitude:       18.068581,
+					Latitude:        59.329323,
+					StreetAddress:   "Drottninggatan 1",
+					PostalCode:      "111 51",
+					City:            "Stockholm",
+					ISO2CountryCode: "SE",
+				},
+				DelayCharging: &vocdriver.DelayCharging{
+					Enabled:   true,
+					StartTime: vocdriver.NewClockTime(22, 0),
+					StopTime:  vocdriver.NewClockTime(6, 0),
+				},
+				Status:                    "Accepted",
+				VehicleAtChargingLocation: true,
+			},
+		},
+		ClimatizationCalendar: vocdriver.ClimatizationCalendar{
+			Timers: []vocdriver.ClimatizationTimer{
+				{ID: 1, Weekdays: vocdriver.WorkingDays, Time: vocdriver.NewClockTime(7, 15), Enabled: true},
+			},
+		},
+	}
+
+	s := &v.Status
+	s.AverageFuelConsumption = 23
+	s.AverageFuelConsumptionTimestamp = timestamp
+	s.AverageSpeed = 42
+	s.AverageSpeedTimestamp = timestamp
+	s.BrakeFluid = "Normal"
+	s.BrakeFluidTimestamp = timestamp
+	s.BulbFailures = []string{}
+	s.BulbFailuresTimestamp = timestamp
+	s.CarLocked = true
+	s.CarLockedTimestamp = timestamp
+	s.ConnectionStatus = "Connected"
+	s.ConnectionStatusTimestamp = timestamp
+	s.DistanceToEmpty = 450
+	s.DistanceToEmptyTimestamp = timestamp
+	s.Doors.Timestamp = timestamp
+	s.EngineRunningTimestamp = timestamp
+	s.FuelAmount = 40
+	s.FuelAmountTimestamp = timestamp
+	s.FuelAmountLevel = 57
+	s.FuelAmountLevelTimestamp = timestamp
+	s.Heater.Status = "off"
+	s.Heater.Timer1.Time = vocdriver.NewClockTime(7, 30)
+	s.Heater.Timer2.Time = vocdriver.NewClockTime(16, 45)
+	s.Heater.Timestamp = timestamp
+	s.HvBattery.HvBatteryChargeStatusDerived = "CablePluggedInCar_FullyCharged"
+	s.HvBattery.HvBatteryChargeStatusDerivedTimestamp = timestamp
+	s.HvBattery.HvBatteryLevel = 100
+	s.HvBattery.HvBatteryLevelTimestamp = timestamp
+	s.HvBattery.DistanceToHVBatteryEmpty = 42
+	s.HvBattery.DistanceToHVBatteryEmptyTimestamp = timestamp
+	s.Odometer = 42194500
+	s.OdometerTimestamp = timestamp
+	s.RemoteClimatizationStatus = "off"
+	s.RemoteClimatizationStatusTimestamp = timestamp
+	s.ServiceWarningStatus = "NoWarning"
+	s.ServiceWarningStatusTimestamp = timestamp
+	s.TripMeter1 = 1234000
+	s.TripMeter1Timestamp = timestamp
+	s.TripMeter2 = 567000
+	s.TripMeter2Timestamp = timestamp
+	s.WasherFluidLevel = "Normal"
+	s.WasherFluidLevelTimestamp = timestamp
+	s.Windows.Timestamp = timestamp
+	return v
+}
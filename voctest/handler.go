@@ -0,0 +1,511 @@
+package voctest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+)
+
+// apiPrefix is the path prefix of the real VOC API. Requests are accepted both with and without it
+const apiPrefix = "/customerapi/rest/v3.0"
+
+// remoteCommands lists the path of each remote command (relative to /vehicles/{vin})
+var remoteCommands = map[string]bool{
+	"lock":                   true,
+	"unlock":                 true,
+	"honk_blink/lights":      true,
+	"honkAndBlink":           true,
+	"engine/start":           true,
+	"engine/stop":            true,
+	"heater/start":           true,
+	"heater/stop":            true,
+	"preclimatization/start": true,
+	"preclimatization/stop":  true,
+	"pois":                   true,
+	"overrideDelayCharging":  true,
+}
+
+// serviceTypes maps the remote commands to the service type codes observed in responses of the real API
+var serviceTypes = map[string]vocdriver.ServiceType{
+	"lock":              vocdriver.ServiceTypeLock,
+	"unlock":            vocdriver.ServiceTypeUnlock,
+	"honk_blink/lights": vocdriver.ServiceTypeBlinkLights,
+}
+
+// serviceType returns the service type reported for command. Commands of unknown codes report their path instead
+func serviceType(command string) vocdriver.ServiceType {
+	if st, ok := serviceTypes[command]; ok {
+		return st
+	}
+	return vocdriver.ServiceType(command)
+}
+
+// Request is a request received by a Handler
+type Request struct {
+	Method string
+	Path   string     // path relative to the API root, e.g.: /vehicles/YV1ABCDEFGH123456/lock
+	Query  url.Values // e.g.: limit=10 of /vehicles/YV1ABCDEFGH123456/trips?limit=10
+	Body   []byte
+}
+
+// Handler is an http.Handler implementing the VOC API on top of Fixtures
+type Handler struct {
+	mu            sync.Mutex
+	fixtures      *Fixtures
+	scenario      Scenario
+	services      map[string]*service // by customerServiceId
+	requests      []Request
+	nextServiceID int
+	clock         func() time.Time // see SetClock
+}
+
+// NewHandler returns a Handler serving a copy of the given fixtures. If fixtures is nil, DefaultFixtures() is used
+func NewHandler(fixtures *Fixtures) *Handler {
+	if fixtures == nil {
+		fixtures = DefaultFixtures()
+	}
+	f := copyFixtures(fixtures)
+	if f.ServiceStatus == "" {
+		f.ServiceStatus = vocdriver.ServiceStatusSuccessful
+	}
+	for i := range f.Vehicles {
+		v := &f.Vehicles[i]
+		if v.RelationID == 0 {
+			v.RelationID = i + 1
+		}
+		if v.Attributes.Vin == "" && v.Attributes.VinLower == "" {
+			v.Attributes.Vin = v.VIN
+		}
+		for j := range v.ChargingLocations {
+			if v.ChargingLocations[j].ChargeLocation == "" {
+				v.ChargingLocations[j].ChargeLocation = strconv.Itoa(j + 1)
+			}
+		}
+	}
+	return &Handler{
+		fixtures: f,
+		services: map[string]*service{},
+	}
+}
+
+// Requests returns every request received so far
+func (h *Handler) Requests() []Request {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]Request(nil), h.requests...)
+}
+
+// Vehicle returns a copy of the current state of the vehicle identified by vin
+func (h *Handler) Vehicle(vin string) (Vehicle, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	v := h.vehicle(vin)
+	if v == nil {
+		return Vehicle{}, false
+	}
+	return copyVehicle(*v), true
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequest", err.Error())
+		return
+	}
+
+	base := "http://" + r.Host
+	if r.TLS != nil {
+		base = "https://" + r.Host
+	}
+	p := r.URL.Path
+	if strings.HasPrefix(p, apiPrefix+"/") {
+		p = strings.TrimPrefix(p, apiPrefix)
+		base += apiPrefix
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.requests = append(h.requests, Request{Method: r.Method, Path: p, Query: r.URL.Query(), Body: body})
+	h.advance(h.now())
+
+	if h.fixtures.Username != "" {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != h.fixtures.Username || password != h.fixtures.Password {
+			writeError(w, http.StatusUnauthorized, "InvalidCredentials", "Invalid username or password")
+			return
+		}
+	}
+
+	parts := strings.Split(strings.Trim(p, "/"), "/")
+	switch {
+	case len(parts) == 1 && parts[0] == "customeraccounts" && r.Method == http.MethodGet:
+		h.serveAccount(w, base)
+	case len(parts) == 2 && parts[0] == "vehicle-account-relations" && r.Method == http.MethodGet:
+		h.serveRelation(w, base, parts[1])
+	case len(parts) >= 2 && parts[0] == "vehicles":
+		h.serveVehicle(w, r.Method, base, parts[1], parts[2:], r.URL.Query(), body)
+	default:
+		writeError(w, http.StatusNotFound, "NotFound", fmt.Sprintf("%s %s was not found", r.Method, p))
+	}
+}
+
+func (h *Handler) serveAccount(w http.ResponseWriter, base string) {
+	relations := []string{}
+	for _, v := range h.fixtures.Vehicles {
+		relations = append(relations, fmt.Sprintf("%s/vehicle-account-relations/%d", base, v.RelationID))
+	}
+	a := h.fixtures.Account
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"username":                a.Username,
+		"firstName":               a.FirstName,
+		"lastName":                a.LastName,
+		"accountId":               a.AccountID,
+		"account":                 base + "/customeraccounts",
+		"accountVehicleRelations": relations,
+	})
+}
+
+func (h *Handler) serveRelation(w http.ResponseWriter, base, id string) {
+	for _, v := range h.fixtures.Vehicles {
+		if strconv.Itoa(v.RelationID) != id {
+			continue
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"vehicleId":                 v.VIN,
+			"username":                  h.fixtures.Account.Username,
+			"status":                    "Verified",
+			"customerVehicleRelationId": v.RelationID,
+			"accountId":                 h.fixtures.Account.AccountID,
+			"account":                   base + "/customeraccounts",
+			"accountVehicleRelation":    fmt.Sprintf("%s/vehicle-account-relations/%d", base, v.RelationID),
+			"vehicle":                   base + "/vehicles/" + v.VIN,
+		})
+		return
+	}
+	writeError(w, http.StatusNotFound, "NotFound", fmt.Sprintf("vehicle-account-relation %s was not found", id))
+}
+
+func (h *Handler) serveVehicle(w http.ResponseWriter, method, base, vin string, rest []string, query url.Values, body []byte) {
+	v := h.vehicle(vin)
+	if v == nil {
+		writeError(w, http.StatusNotFound, "VehicleNotFound", fmt.Sprintf("vehicle %s was not found", vin))
+		return
+	}
+	vehicleURL := base + "/vehicles/" + vin
+	resource := strings.Join(rest, "/")
+
+	if method == http.MethodPost {
+		if remoteCommands[resource] {
+			if resource == "engine/start" {
+				var payload struct {
+					Runtime int `json:"runtime"`
+				}
+				if err := json.Unmarshal(body, &payload); err != nil || payload.Runtime < vocdriver.MinEngineRuntime || payload.Runtime > vocdriver.MaxEngineRuntime {
+					writeError(w, http.StatusBadRequest, "InvalidRequest", fmt.Sprintf("runtime must be between %d and %d minutes", vocdriver.MinEngineRuntime, vocdriver.MaxEngineRuntime))
+					return
+				}
+			}
+			if resource == "pois" {
+				var poi vocdriver.POI
+				if err := json.Unmarshal(body, &poi); err != nil || poi.Name == "" || (poi.Position.Latitude == 0 && poi.Position.Longitude == 0) {
+					writeError(w, http.StatusBadRequest, "InvalidRequest", "a named point of interest with coordinates is required")
+					return
+				}
+			}
+			writeJSON(w, http.StatusOK, h.startService(vehicleURL, v, resource, body))
+			return
+		}
+	}
+
+	switch {
+	case method == http.MethodGet && resource == "":
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"vehicleId":               vin,
+			"attributes":              vehicleURL + "/attributes",
+			"status":                  vehicleURL + "/status",
+			"vehicleAccountRelations": []string{fmt.Sprintf("%s/vehicle-account-relations/%d", base, v.RelationID)},
+		})
+	case method == http.MethodGet && resource == "attributes":
+		writeJSON(w, http.StatusOK, v.Attributes)
+	case method == http.MethodGet && resource == "status":
+		writeJSON(w, http.StatusOK, v.Status)
+	case method == http.MethodGet && resource == "position":
+		writeJSON(w, http.StatusOK, v.Position)
+	case method == http.MethodGet && resource == "trips":
+		trips, err := queryTrips(v.Trips, query)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequest", err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"trips": trips})
+	case method == http.MethodGet && resource == "chargeLocations":
+		locations := []vocdriver.ChargingLocation{}
+		for _, cl := range v.ChargingLocations {
+			locations = append(locations, chargingLocationResponse(vehicleURL, cl))
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"chargingLocations": locations})
+	case method == http.MethodPost && resource == "chargeLocations":
+		var cl vocdriver.ChargingLocation
+		if err := json.Unmarshal(body, &cl); err != nil || cl.Name == "" || cl.Position == nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequest", "a named charging location with a position is required")
+			return
+		}
+		cl.ChargeLocation = nextChargingLocationID(v)
+		cl.Status = "Accepted"
+		v.ChargingLocations = append(v.ChargingLocations, cl)
+		writeJSON(w, http.StatusOK, chargingLocationResponse(vehicleURL, cl))
+	case len(rest) == 2 && rest[0] == "chargeLocations":
+		h.serveChargingLocation(w, method, vehicleURL, v, rest[1], body)
+	case len(rest) == 2 && rest[0] == "trips":
+		h.serveTrip(w, method, v, rest[1], body)
+	case method == http.MethodPut && resource == "heater/timers/1", method == http.MethodPut && resource == "heater/timers/2":
+		timer := &v.Status.Heater.Timer1
+		if rest[2] == "2" {
+			timer = &v.Status.Heater.Timer2
+		}
+		var ht vocdriver.HeaterTimer
+		if err := json.Unmarshal(body, &ht); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequest", err.Error())
+			return
+		}
+		if ht.Time.IsZero() {
+			writeError(w, http.StatusBadRequest, "InvalidRequest", "time is required")
+			return
+		}
+		*timer = ht
+		writeJSON(w, http.StatusOK, ht)
+	case method == http.MethodPut && resource == "heater/seatSelection":
+		if err := json.Unmarshal(body, &v.Status.Heater.SeatSelection); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequest", err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, v.Status.Heater.SeatSelection)
+	case method == http.MethodGet && resource == "climatizationCalendar":
+		writeJSON(w, http.StatusOK, v.ClimatizationCalendar)
+	case method == http.MethodPut && resource == "climatizationCalendar":
+		var cc vocdriver.ClimatizationCalendar
+		if err := json.Unmarshal(body, &cc); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequest", err.Error())
+			return
+		}
+		if limit := v.Attributes.ClimatizationCalendarMaxTimers; len(cc.Timers) > limit {
+			writeError(w, http.StatusBadRequest, "TooManyTimers", fmt.Sprintf("at most %d timers are allowed", limit))
+			return
+		}
+		v.ClimatizationCalendar = cc
+		writeJSON(w, http.StatusOK, cc)
+	case method == http.MethodGet && len(rest) == 2 && rest[0] == "services":
+		svc, ok := h.services[rest[1]]
+		if !ok || svc.status.VehicleID != vin {
+			writeError(w, http.StatusNotFound, "ServiceNotFound", fmt.Sprintf("service %s was not found", rest[1]))
+			return
+		}
+		writeJSON(w, http.StatusOK, svc.status)
+	default:
+		writeError(w, http.StatusNotFound, "NotFound", fmt.Sprintf("%s /vehicles/%s/%s was not found", method, vin, resource))
+	}
+}
+
+func (h *Handler) serveChargingLocation(w http.ResponseWriter, method, vehicleURL string, v *Vehicle, id string, body []byte) {
+	for i := range v.ChargingLocations {
+		cl := &v.ChargingLocations[i]
+		if path.Base(cl.ChargeLocation) != id {
+			continue
+		}
+		switch method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, chargingLocationResponse(vehicleURL, *cl))
+		case http.MethodPut:
+			// fields missing from the payload keep their current value
+			if err := json.Unmarshal(body, cl); err != nil {
+				writeError(w, http.StatusBadRequest, "InvalidRequest", err.Error())
+				return
+			}
+			cl.ChargeLocation = id
+			writeJSON(w, http.StatusOK, chargingLocationResponse(vehicleURL, *cl))
+		case http.MethodDelete:
+			v.ChargingLocations = append(v.ChargingLocations[:i], v.ChargingLocations[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", method+" is not allowed")
+		}
+		return
+	}
+	writeError(w, http.StatusNotFound, "ChargeLocationNotFound", fmt.Sprintf("charging location %s was not found", id))
+}
+
+// startService registers a new remote command in the Started state
+func (h *Handler) startService(vehicleURL string, v *Vehicle, command string, body []byte) *vocdriver.VehicleServiceStatus {
+	h.nextServiceID++
+	id := strconv.Itoa(h.nextServiceID)
+	now := h.now()
+	svc := &service{
+		command: command,
+		body:    body,
+		started: now,
+		status: vocdriver.VehicleServiceStatus{
+			Status:            vocdriver.ServiceStatusStarted,
+			StatusTimestamp:   vocdriver.NewTimestamp(now),
+			StartTime:         vocdriver.NewTimestamp(now),
+			ServiceType:       serviceType(command),
+			Service:           vehicleURL + "/services/" + id,
+			VehicleID:         v.VIN,
+			CustomerServiceID: id,
+		},
+	}
+	h.services[id] = svc
+	return &svc.status
+}
+
+func (h *Handler) vehicle(vin string) *Vehicle {
+	for i := range h.fixtures.Vehicles {
+		if h.fixtures.Vehicles[i].VIN == vin {
+			return &h.fixtures.Vehicles[i]
+		}
+	}
+	return nil
+}
+
+func (h *Handler) serveTrip(w http.ResponseWriter, method string, v *Vehicle, id string, body []byte) {
+	for i := range v.Trips {
+		trip := &v.Trips[i]
+		if strconv.Itoa(trip.ID) != id {
+			continue
+		}
+		switch method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, trip)
+		case http.MethodPut:
+			var update vocdriver.TripUpdate
+			if err := json.Unmarshal(body, &update); err != nil {
+				writeError(w, http.StatusBadRequest, "InvalidRequest", err.Error())
+				return
+			}
+			if update.Category != nil && !update.Category.IsValid() {
+				writeError(w, http.StatusBadRequest, "InvalidRequest", fmt.Sprintf("unknown trip category %q", *update.Category))
+				return
+			}
+			// fields missing from the payload keep their current value
+			if update.Name != nil {
+				trip.Name = *update.Name
+			}
+			if update.Category != nil {
+				trip.Category = *update.Category
+			}
+			if update.UserNotes != nil {
+				trip.UserNotes = *update.UserNotes
+			}
+			writeJSON(w, http.StatusOK, trip)
+		case http.MethodDelete:
+			v.Trips = append(v.Trips[:i], v.Trips[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", method+" is not allowed")
+		}
+		return
+	}
+	writeError(w, http.StatusNotFound, "TripNotFound", fmt.Sprintf("trip %s was not found", id))
+}
+
+// queryTrips returns the trips matching the since, until and category parameters, paged by limit and offset
+func queryTrips(trips []vocdriver.Trip, query url.Values) ([]vocdriver.Trip, error) {
+	var (
+		q   vocdriver.TripsQuery
+		err error
+	)
+	for name, dst := range map[string]*time.Time{"since": &q.Since, "until": &q.Until} {
+		if query.Get(name) == "" {
+			continue
+		}
+		ts, err := vocdriver.ParseTimestamp(query.Get(name))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", name, err)
+		}
+		*dst = ts.Time
+	}
+	for name, dst := range map[string]*int{"limit": &q.Limit, "offset": &q.Offset} {
+		if query.Get(name) == "" {
+			continue
+		}
+		if *dst, err = strconv.Atoi(query.Get(name)); err != nil || *dst < 0 {
+			return nil, fmt.Errorf("%s must be a non-negative number, got %q", name, query.Get(name))
+		}
+	}
+	q.Category = vocdriver.TripCategory(query.Get("category"))
+
+	matching := []vocdriver.Trip{}
+	for _, trip := range trips {
+		if q.Matches(trip) {
+			matching = append(matching, trip)
+		}
+	}
+	if q.Offset >= len(matching) {
+		return []vocdriver.Trip{}, nil
+	}
+	matching = matching[q.Offset:]
+	if q.Limit > 0 && len(matching) > q.Limit {
+		matching = matching[:q.Limit]
+	}
+	return matching, nil
+}
+
+// nextChargingLocationID returns an ID greater than the numeric ID of every charging location of v
+func nextChargingLocationID(v *Vehicle) string {
+	next := 1
+	for _, cl := range v.ChargingLocations {
+		if id, err := strconv.Atoi(path.Base(cl.ChargeLocation)); err == nil && id >= next {
+			next = id + 1
+		}
+	}
+	return strconv.Itoa(next)
+}
+
+// chargingLocationResponse returns cl with its ChargeLocation ID expanded to an absolute URL
+func chargingLocationResponse(vehicleURL string, cl vocdriver.ChargingLocation) vocdriver.ChargingLocation {
+	cl.ChargeLocation = vehicleURL + "/chargeLocations/" + path.Base(cl.ChargeLocation)
+	return cl
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, label, description string) {
+	writeJSON(w, statusCode, map[string]string{
+		"errorLabel":       label,
+		"errorDescription": description,
+	})
+}
+
+// copyFixtures deep-copies f so the seed passed in by the caller is never modified
+func copyFixtures(f *Fixtures) *Fixtures {
+	c := *f
+	c.Vehicles = make([]Vehicle, len(f.Vehicles))
+	for i, v := range f.Vehicles {
+		c.Vehicles[i] = copyVehicle(v)
+	}
+	return &c
+}
+
+func copyVehicle(v Vehicle) Vehicle {
+	var c Vehicle
+	b, err := json.Marshal(v)
+	if err == nil {
+		err = json.Unmarshal(b, &c)
+	}
+	if err != nil {
+		panic(fmt.Sprintf("voctest: failed to copy vehicle %s: %v", v.VIN, err))
+	}
+	return c
+}
@@ -0,0 +1,184 @@
+package voctest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+)
+
+// Scenario configures how the simulated vehicles react to remote commands
+//
+// Every remote command progresses through Started -> MessageDelivered -> Successful/Failed.
+// Successful commands change the state of the vehicle, e.g.: a lock sets VehicleStatus.CarLocked.
+type Scenario struct {
+	Username        string            `json:"username,omitempty"`        // overrides Fixtures.Username if set
+	Password        string            `json:"password,omitempty"`        // overrides Fixtures.Password if set
+	DeliveryDelay   Duration          `json:"deliveryDelay,omitempty"`   // time spent in Started before MessageDelivered
+	CompletionDelay Duration          `json:"completionDelay,omitempty"` // time spent in MessageDelivered before the terminal status
+	Offline         bool              `json:"offline,omitempty"`         // the vehicles are not connected; commands never leave Started
+	Failures        map[string]string `json:"failures,omitempty"`        // failure reason by service type (e.g.: RDU) or command path (e.g.: engine/start)
+}
+
+// Duration is a time.Duration encoded in JSON either as a string (e.g.: "1.5s") or as a number of seconds
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		parsed, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		*d = Duration(parsed)
+		return nil
+	}
+	seconds, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return fmt.Errorf("invalid duration %s", data)
+	}
+	*d = Duration(seconds * float64(time.Second))
+	return nil
+}
+
+// LoadScenario reads a JSON encoded Scenario from the file at path
+func LoadScenario(path string) (scenario Scenario, err error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(b, &scenario)
+	return
+}
+
+// SetScenario changes the behaviour of the Handler. Commands already in progress are affected too
+func (h *Handler) SetScenario(scenario Scenario) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.scenario = scenario
+	if scenario.Username != "" {
+		h.fixtures.Username = scenario.Username
+		h.fixtures.Password = scenario.Password
+	}
+	connectionStatus := "Connected"
+	if scenario.Offline {
+		connectionStatus = "Disconnected"
+	}
+	now := vocdriver.NewTimestamp(h.now())
+	for i := range h.fixtures.Vehicles {
+		h.fixtures.Vehicles[i].Status.ConnectionStatus = connectionStatus
+		h.fixtures.Vehicles[i].Status.ConnectionStatusTimestamp = now
+	}
+}
+
+// SetClock replaces the clock the Scenario delays are measured with (time.Now by default), e.g.: to advance a fake
+// clock in tests instead of sleeping
+func (h *Handler) SetClock(now func() time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.clock = now
+}
+
+// now returns the current time of the clock. h.mu must be held
+func (h *Handler) now() time.Time {
+	if h.clock != nil {
+		return h.clock()
+	}
+	return time.Now()
+}
+
+// service is a remote command tracked by the Handler
+type service struct {
+	command string // path relative to /vehicles/{vin}
+	body    []byte // payload of the command, e.g.: the POI sent to the car
+	started time.Time
+	status  vocdriver.VehicleServiceStatus
+}
+
+// advance moves every service in progress forward according to the Scenario
+func (h *Handler) advance(now time.Time) {
+	delivered := time.Duration(h.scenario.DeliveryDelay)
+	completed := delivered + time.Duration(h.scenario.CompletionDelay)
+	for _, svc := range h.services {
+		st := &svc.status
+		elapsed := now.Sub(svc.started)
+		switch {
+		case st.Status.IsTerminal():
+			continue
+		case h.scenario.Offline:
+			continue
+		case elapsed >= completed:
+			h.complete(svc, svc.started.Add(completed))
+		case elapsed >= delivered && st.Status == vocdriver.ServiceStatusStarted:
+			st.Status = vocdriver.ServiceStatusMessageDelivered
+			st.StatusTimestamp = vocdriver.NewTimestamp(svc.started.Add(delivered))
+		}
+	}
+}
+
+// complete moves svc to its terminal status and applies its effect on the vehicle if it succeeded
+func (h *Handler) complete(svc *service, at time.Time) {
+	st := &svc.status
+	st.StatusTimestamp = vocdriver.NewTimestamp(at)
+
+	reason, failed := h.scenario.Failures[string(st.ServiceType)]
+	if !failed {
+		reason, failed = h.scenario.Failures[svc.command]
+	}
+	switch {
+	case failed:
+		st.Status = vocdriver.ServiceStatusFailed
+		st.FailureReason = reason
+		return
+	case !h.fixtures.ServiceStatus.IsSuccess():
+		st.Status = h.fixtures.ServiceStatus
+		st.FailureReason = h.fixtures.FailureReason
+		return
+	}
+	st.Status = vocdriver.ServiceStatusSuccessful
+
+	v := h.vehicle(st.VehicleID)
+	if v == nil {
+		return
+	}
+	ts := vocdriver.NewTimestamp(at)
+	s := &v.Status
+	switch svc.command {
+	case "lock", "unlock":
+		s.CarLocked = svc.command == "lock"
+		s.CarLockedTimestamp = ts
+	case "engine/start", "engine/stop":
+		s.EngineRunning = svc.command == "engine/start"
+		s.EngineRunningTimestamp = ts
+	case "heater/start", "heater/stop":
+		s.Heater.Status = onOff(svc.command == "heater/start")
+		s.Heater.Timestamp = ts
+	case "preclimatization/start", "preclimatization/stop":
+		s.Heater.Status = onOff(svc.command == "preclimatization/start")
+		s.Heater.Timestamp = ts
+		s.RemoteClimatizationStatus = s.Heater.Status
+		s.RemoteClimatizationStatusTimestamp = ts
+	case "overrideDelayCharging":
+		s.HvBattery.HvBatteryChargeStatusDerived = "CablePluggedInCar_Charging"
+		s.HvBattery.HvBatteryChargeStatusDerivedTimestamp = ts
+	case "pois":
+		var poi vocdriver.POI
+		if err := json.Unmarshal(svc.body, &poi); err == nil {
+			v.POIs = append(v.POIs, poi)
+		}
+	}
+}
+
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
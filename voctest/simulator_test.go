@@ -0,0 +1,121 @@
+package voctest_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+	"github.com/theriverman/VolvoOnCall/voctest"
+)
+
+// fakeClock is a clock advanced by the test
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestSimulator_Lifecycle(t *testing.T) {
+	srv := voctest.NewServer(nil)
+	defer srv.Close()
+	clock := &fakeClock{now: time.Now()}
+	srv.Handler.SetClock(clock.Now)
+	srv.Handler.SetScenario(voctest.Scenario{
+		DeliveryDelay:   voctest.Duration(200 * time.Millisecond),
+		CompletionDelay: voctest.Duration(200 * time.Millisecond),
+		Failures:        map[string]string{"engine/start": "EngineStartNotAllowed"},
+	})
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	vss, err := client.Vehicles.UnlockVehicle(ctx, voctest.VIN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var observed []vocdriver.ServiceStatus
+	for _, wait := range []time.Duration{0, 300 * time.Millisecond, 200 * time.Millisecond} {
+		clock.Advance(wait)
+		if err = vss.Refresh(ctx); err != nil {
+			t.Fatal(err)
+		}
+		observed = append(observed, vss.Status)
+	}
+	if observed[0] != "Started" || observed[1] != "MessageDelivered" || observed[2] != "Successful" {
+		t.Errorf("unexpected lifecycle: %v", observed)
+	}
+	if v, _ := srv.Handler.Vehicle(voctest.VIN); v.Status.CarLocked {
+		t.Error("expected the car to be unlocked")
+	}
+
+	vss, err = client.Vehicles.StartEngine(ctx, voctest.VIN, vocdriver.MaxEngineRuntime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(500 * time.Millisecond)
+	if err = vss.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if vss.Status != "Failed" || vss.FailureReason != "EngineStartNotAllowed" {
+		t.Errorf("expected injected failure, got %s (%s)", vss.Status, vss.FailureReason)
+	}
+	if v, _ := srv.Handler.Vehicle(voctest.VIN); v.Status.EngineRunning {
+		t.Error("expected the engine to remain stopped")
+	}
+}
+
+func TestSimulator_Offline(t *testing.T) {
+	srv := voctest.NewServer(nil)
+	defer srv.Close()
+	srv.Handler.SetScenario(voctest.Scenario{Offline: true})
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancel()
+	vss, err := client.Vehicles.LockVehicle(ctx, voctest.VIN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = client.Vehicles.EvaluateServiceStatus(ctx, vss, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the deadline to be exceeded, got %v", err)
+	}
+	if vss.Status != "Started" {
+		t.Errorf("expected the command to remain Started, got %s", vss.Status)
+	}
+}
+
+func TestLoadScenario(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scenario.json")
+	err := os.WriteFile(path, []byte(`{"deliveryDelay": "2s", "completionDelay": 0.5, "offline": true, "failures": {"RDU": "Timeout"}}`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scenario, err := voctest.LoadScenario(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if time.Duration(scenario.DeliveryDelay) != 2*time.Second || time.Duration(scenario.CompletionDelay) != 500*time.Millisecond || !scenario.Offline || scenario.Failures["RDU"] != "Timeout" {
+		t.Errorf("unexpected scenario: %+v", scenario)
+	}
+}
@@ -0,0 +1,48 @@
+// Package voctest provides an offline fake of the Volvo On Call API for testing code built on top of vocdriver
+//
+// A Server serves the customeraccounts, vehicle-account-relations, vehicles/{vin}/... and services endpoints
+// from seedable Fixtures, so both the library and the voc CLI can be exercised without a real car:
+//
+//	srv := voctest.NewServer(nil) // nil seeds DefaultFixtures()
+//	defer srv.Close()
+//	client, err := srv.NewClient()
+package voctest
+
+import (
+	"net/http/httptest"
+
+	vocdriver "github.com/theriverman/VolvoOnCall"
+)
+
+// Server is an httptest.Server running a Handler
+type Server struct {
+	*httptest.Server
+	Handler *Handler
+}
+
+// NewServer starts a Server seeded with the given fixtures. If fixtures is nil, DefaultFixtures() is used
+//
+// The caller must call Close when finished to shut it down
+func NewServer(fixtures *Fixtures) *Server {
+	h := NewHandler(fixtures)
+	return &Server{
+		Server:  httptest.NewServer(h),
+		Handler: h,
+	}
+}
+
+// NewClient returns an initialised *vocdriver.Client pointed at the Server and authenticated with the seeded credentials
+//
+// Additional options (e.g.: vocdriver.WithLogger) are applied after the defaults
+func (s *Server) NewClient(opts ...vocdriver.Option) (*vocdriver.Client, error) {
+	defaults := []vocdriver.Option{
+		vocdriver.WithBaseURL(s.URL),
+		vocdriver.WithHTTPClient(s.Client()),
+	}
+	s.Handler.mu.Lock()
+	if f := s.Handler.fixtures; f.Username != "" {
+		defaults = append(defaults, vocdriver.WithCredentials(f.Username, f.Password))
+	}
+	s.Handler.mu.Unlock()
+	return vocdriver.NewClient(append(defaults, opts...)...)
+}